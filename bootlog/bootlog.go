@@ -0,0 +1,209 @@
+package bootlog
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pausing-clusters-thesis/benchmarks/results"
+)
+
+type Milestone string
+
+const (
+	ProcessStarted            Milestone = "process-started"
+	CommitlogReplayStarted    Milestone = "commitlog-replay-started"
+	CommitlogReplayFinished   Milestone = "commitlog-replay-finished"
+	SystemSSTablesLoadStarted Milestone = "system-sstables-load-started"
+	UserSSTablesLoadStarted   Milestone = "user-sstables-load-started"
+	GossipSettleStarted       Milestone = "gossip-settle-started"
+	GossipSettled             Milestone = "gossip-settled"
+	CQLServerStarted          Milestone = "cql-server-started"
+	Serving                   Milestone = "serving"
+)
+
+// Milestones lists all milestones in the order ScyllaDB reaches them during boot.
+var Milestones = []Milestone{
+	ProcessStarted,
+	SystemSSTablesLoadStarted,
+	UserSSTablesLoadStarted,
+	CommitlogReplayStarted,
+	CommitlogReplayFinished,
+	GossipSettleStarted,
+	GossipSettled,
+	CQLServerStarted,
+	Serving,
+}
+
+var (
+	scyllaLogLineRegex = regexp.MustCompile(`^(?:TRACE|DEBUG|INFO|WARN|ERROR)\s+\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(?:,\d+)?\s+(?:\[shard\s+\d+(?::\w+)?\]\s+)?(.*)$`)
+
+	milestoneMessageRegexes = []struct {
+		milestone Milestone
+		regex     *regexp.Regexp
+	}{
+		// ScyllaDB logs both of the start lines, in this order, when its process starts.
+		{milestone: ProcessStarted, regex: regexp.MustCompile(`^starting ScyllaDB\.{3}`)},
+		{milestone: ProcessStarted, regex: regexp.MustCompile(`^init - Scylla version .* starting`)},
+		{milestone: SystemSSTablesLoadStarted, regex: regexp.MustCompile(`^init - loading system sstables`)},
+		{milestone: UserSSTablesLoadStarted, regex: regexp.MustCompile(`^init - loading non-system sstables`)},
+		{milestone: CommitlogReplayStarted, regex: regexp.MustCompile(`^init - replaying commit log$`)},
+		{milestone: CommitlogReplayFinished, regex: regexp.MustCompile(`^(?:commitlog_replayer - Log replay complete|init - replaying commit log - removing old commitlog segments)`)},
+		{milestone: GossipSettleStarted, regex: regexp.MustCompile(`^gossip - Waiting for gossip to settle`)},
+		{milestone: GossipSettled, regex: regexp.MustCompile(`^gossip - (?:No gossip backlog; proceeding|Gossip settled after \d+ extra polls; proceeding)`)},
+		{milestone: CQLServerStarted, regex: regexp.MustCompile(`^cql_server_controller - Starting listening for CQL clients`)},
+		{milestone: Serving, regex: regexp.MustCompile(`^init - serving$`)},
+	}
+)
+
+const (
+	maxLineSize = 1024 * 1024
+)
+
+type Event struct {
+	Milestone Milestone
+	Time      time.Time
+}
+
+// Boot holds the milestones reached by the last ScyllaDB process found in the logs, in the order they were logged.
+type Boot struct {
+	Events []Event
+}
+
+func (b *Boot) Time(milestone Milestone) (time.Time, bool) {
+	for _, e := range b.Events {
+		if e.Milestone == milestone {
+			return e.Time, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// Phases returns the boot phases, each ending at a reached milestone, relative to the start of the process.
+func (b *Boot) Phases() ([]results.Phase, error) {
+	startTime, ok := b.Time(ProcessStarted)
+	if !ok {
+		return nil, fmt.Errorf("milestone %q wasn't reached", ProcessStarted)
+	}
+
+	var phases []results.Phase
+	previousTime := startTime
+	for _, e := range b.Events {
+		if e.Milestone == ProcessStarted {
+			continue
+		}
+
+		phases = append(phases, results.Phase{
+			Name:       string(e.Milestone),
+			OffsetMs:   e.Time.Sub(startTime).Milliseconds(),
+			DurationMs: max(e.Time.Sub(previousTime), 0).Milliseconds(),
+		})
+		previousTime = e.Time
+	}
+
+	return phases, nil
+}
+
+// Parse reads ScyllaDB container logs, with every line prefixed by the timestamp added by Kubernetes,
+// and returns the first time each milestone was reached.
+// When the process was restarted within the logs, only the milestones of the last process are kept.
+func Parse(r io.Reader) (*Boot, error) {
+	boot := &Boot{}
+	reached := map[Milestone]bool{}
+	// lastStartLine is the index of the regex of the last start line logged by the last process.
+	lastStartLine := -1
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		timestampString, message, ok := strings.Cut(scanner.Text(), " ")
+		if !ok {
+			continue
+		}
+
+		milestone, regexIdx, ok := matchMilestone(message)
+		if !ok {
+			continue
+		}
+
+		timestamp, err := ParseTimestamp(timestampString)
+		if err != nil {
+			return nil, fmt.Errorf("can't parse timestamp on line %d: %w", lineNumber, err)
+		}
+
+		// ScyllaDB logs more than a single line when its process starts, so a start line is only considered
+		// a restart if it follows other milestones, or if it doesn't follow the previous start line in the order
+		// they are logged in, e.g. when the process crashed right after starting.
+		if milestone == ProcessStarted {
+			restarted := regexIdx <= lastStartLine || slices.ContainsFunc(boot.Events, func(e Event) bool {
+				return e.Milestone != ProcessStarted
+			})
+			if restarted {
+				boot.Events = nil
+				clear(reached)
+			}
+			lastStartLine = regexIdx
+		}
+
+		if reached[milestone] {
+			continue
+		}
+		reached[milestone] = true
+
+		boot.Events = append(boot.Events, Event{
+			Milestone: milestone,
+			Time:      timestamp,
+		})
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("can't read logs: %w", err)
+	}
+
+	return boot, nil
+}
+
+// matchMilestone returns the milestone logged in the message, along with the index of the regex that matched it.
+func matchMilestone(message string) (Milestone, int, bool) {
+	matches := scyllaLogLineRegex.FindStringSubmatch(strings.TrimSpace(message))
+	if matches == nil {
+		return "", 0, false
+	}
+
+	for i, mr := range milestoneMessageRegexes {
+		if mr.regex.MatchString(matches[1]) {
+			return mr.milestone, i, true
+		}
+	}
+
+	return "", 0, false
+}
+
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",
+}
+
+// ParseTimestamp parses the timestamps Kubernetes prefixes log lines with, which are RFC 3339 timestamps
+// with an optional fractional second of any precision and either a "Z" or a numeric time zone offset.
+func ParseTimestamp(s string) (time.Time, error) {
+	var firstErr error
+	for _, layout := range timestampLayouts {
+		t, err := time.Parse(layout, s)
+		if err == nil {
+			return t, nil
+		}
+
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return time.Time{}, firstErr
+}
@@ -0,0 +1,213 @@
+package bootlog
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pausing-clusters-thesis/benchmarks/results"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name        string
+		timestamp   string
+		expected    time.Time
+		expectedErr bool
+	}{
+		{
+			name:      "nanoseconds in UTC",
+			timestamp: "2025-03-01T10:00:00.123456789Z",
+			expected:  time.Date(2025, 3, 1, 10, 0, 0, 123456789, time.UTC),
+		},
+		{
+			name:      "trimmed fractional second in UTC",
+			timestamp: "2025-03-01T10:00:00.12Z",
+			expected:  time.Date(2025, 3, 1, 10, 0, 0, 120000000, time.UTC),
+		},
+		{
+			name:      "no fractional second in UTC",
+			timestamp: "2025-03-01T10:00:00Z",
+			expected:  time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "numeric offset",
+			timestamp: "2025-03-01T11:00:00.123456789+01:00",
+			expected:  time.Date(2025, 3, 1, 10, 0, 0, 123456789, time.UTC),
+		},
+		{
+			name:      "negative numeric offset without fractional second",
+			timestamp: "2025-03-01T05:30:00-04:30",
+			expected:  time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "numeric offset without a colon",
+			timestamp: "2025-03-01T11:00:00.5+0100",
+			expected:  time.Date(2025, 3, 1, 10, 0, 0, 500000000, time.UTC),
+		},
+		{
+			name:        "no time zone",
+			timestamp:   "2025-03-01T10:00:00",
+			expectedErr: true,
+		},
+		{
+			name:        "not a timestamp",
+			timestamp:   "INFO",
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseTimestamp(tc.timestamp)
+			if tc.expectedErr {
+				if err == nil {
+					t.Errorf("expected an error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !got.Equal(tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	at := func(sec int, nsec int) time.Time {
+		return time.Date(2025, 3, 1, 10, 0, sec, nsec, time.UTC)
+	}
+
+	tt := []struct {
+		name           string
+		file           string
+		expectedEvents []Event
+	}{
+		{
+			name: "cold boot with commitlog replay",
+			file: "cold.log",
+			expectedEvents: []Event{
+				{Milestone: ProcessStarted, Time: at(0, 418206001)},
+				{Milestone: SystemSSTablesLoadStarted, Time: at(1, 210411000)},
+				{Milestone: UserSSTablesLoadStarted, Time: at(1, 732000000)},
+				{Milestone: CommitlogReplayStarted, Time: at(2, 10000000)},
+				{Milestone: CommitlogReplayFinished, Time: at(3, 114230108)},
+				{Milestone: GossipSettleStarted, Time: at(4, 0)},
+				{Milestone: GossipSettled, Time: at(12, 2131955)},
+				{Milestone: CQLServerStarted, Time: at(12, 611442109)},
+				{Milestone: Serving, Time: at(12, 913522001)},
+			},
+		},
+		{
+			name: "restarted process with numeric offsets",
+			file: "restarted-with-offset.log",
+			expectedEvents: []Event{
+				{Milestone: ProcessStarted, Time: at(5, 1)},
+				{Milestone: SystemSSTablesLoadStarted, Time: at(5, 500000000)},
+				{Milestone: UserSSTablesLoadStarted, Time: at(6, 0)},
+				{Milestone: CommitlogReplayStarted, Time: at(6, 250000000)},
+				{Milestone: CommitlogReplayFinished, Time: at(6, 500000000)},
+				{Milestone: GossipSettleStarted, Time: at(7, 0)},
+				{Milestone: GossipSettled, Time: at(15, 0)},
+				{Milestone: CQLServerStarted, Time: at(15, 500000000)},
+				{Milestone: Serving, Time: at(16, 0)},
+			},
+		},
+		{
+			name: "crash loop with processes that only logged their start",
+			file: "crash-loop.log",
+			expectedEvents: []Event{
+				{Milestone: ProcessStarted, Time: at(8, 1)},
+				{Milestone: SystemSSTablesLoadStarted, Time: at(8, 500000000)},
+				{Milestone: UserSSTablesLoadStarted, Time: at(9, 0)},
+				{Milestone: CommitlogReplayStarted, Time: at(9, 250000000)},
+				{Milestone: CommitlogReplayFinished, Time: at(9, 500000000)},
+				{Milestone: GossipSettleStarted, Time: at(10, 0)},
+				{Milestone: GossipSettled, Time: at(18, 0)},
+				{Milestone: CQLServerStarted, Time: at(18, 500000000)},
+				{Milestone: Serving, Time: at(19, 0)},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := os.Open(filepath.Join("testdata", tc.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			boot, err := Parse(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(boot.Events) != len(tc.expectedEvents) {
+				t.Fatalf("expected %d events, got %d: %v", len(tc.expectedEvents), len(boot.Events), boot.Events)
+			}
+			for i := range tc.expectedEvents {
+				if boot.Events[i].Milestone != tc.expectedEvents[i].Milestone || !boot.Events[i].Time.Equal(tc.expectedEvents[i].Time) {
+					t.Errorf("expected event %d to be %v, got %v", i, tc.expectedEvents[i], boot.Events[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseInvalidTimestamp(t *testing.T) {
+	t.Parallel()
+
+	_, err := Parse(strings.NewReader("2025-03-01T10:00:00 INFO  2025-03-01 10:00:00,418 starting ScyllaDB...\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Errorf("expected an error referring to line 1, got %v", err)
+	}
+}
+
+func TestBootPhases(t *testing.T) {
+	t.Parallel()
+
+	startTime := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	boot := &Boot{
+		Events: []Event{
+			{Milestone: ProcessStarted, Time: startTime},
+			{Milestone: SystemSSTablesLoadStarted, Time: startTime.Add(time.Second)},
+			{Milestone: GossipSettled, Time: startTime.Add(3 * time.Second)},
+			{Milestone: Serving, Time: startTime.Add(4 * time.Second)},
+		},
+	}
+
+	phases, err := boot.Phases()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []results.Phase{
+		{Name: string(SystemSSTablesLoadStarted), OffsetMs: 1000, DurationMs: 1000},
+		{Name: string(GossipSettled), OffsetMs: 3000, DurationMs: 2000},
+		{Name: string(Serving), OffsetMs: 4000, DurationMs: 1000},
+	}
+	if !reflect.DeepEqual(phases, expected) {
+		t.Errorf("expected phases %#v, got %#v", expected, phases)
+	}
+
+	_, err = (&Boot{}).Phases()
+	if err == nil {
+		t.Errorf("expected an error when the process start is missing")
+	}
+}
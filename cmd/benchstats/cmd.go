@@ -0,0 +1,36 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+const usage = `Usage:
+  benchstats summarize [flags] PATH...
+  benchstats compare [flags] REFERENCE_PATH CURRENT_PATH
+
+Paths point to results files or directories containing them.
+
+Commands:
+  summarize  Print descriptive statistics of each scenario and pairwise significance tests between scenarios.
+  compare    Compare current results with reference results and fail on statistically significant regressions.
+`
+
+func run(args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New("command is required\n" + usage)
+	}
+
+	switch args[0] {
+	case "summarize":
+		return runSummarize(args[1:], out)
+	case "compare":
+		return runCompare(args[1:], out)
+	case "-h", "-help", "--help", "help":
+		_, err := fmt.Fprint(out, usage)
+		return err
+	default:
+		return fmt.Errorf("unknown command %q\n%s", args[0], usage)
+	}
+}
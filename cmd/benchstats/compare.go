@@ -0,0 +1,283 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pausing-clusters-thesis/benchmarks/results"
+	"github.com/pausing-clusters-thesis/benchmarks/stats"
+	"k8s.io/utils/ptr"
+)
+
+type verdict string
+
+const (
+	verdictInsignificant   verdict = "~"
+	verdictWithinThreshold verdict = "within threshold"
+	verdictImprovement     verdict = "improvement"
+	verdictRegression      verdict = "REGRESSION"
+	verdictMissing         verdict = "missing"
+	// verdictTooFewSamples is given when no difference between the samples could be significant at the alpha level.
+	verdictTooFewSamples verdict = "too few samples"
+)
+
+type compareOptions struct {
+	metrics                    string
+	alpha                      float64
+	maxRelativeIncreasePercent float64
+	maxAbsoluteIncreaseMs      float64
+	thresholdsFilePath         string
+
+	referencePath string
+	currentPath   string
+}
+
+func (o *compareOptions) addFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.metrics, "metrics", o.metrics, "Comma-separated list of metrics to compare. All metrics are compared when empty.")
+	fs.Float64Var(&o.alpha, "alpha", o.alpha, "Significance level of the tests.")
+	fs.Float64Var(&o.maxRelativeIncreasePercent, "max-relative-increase-percent", o.maxRelativeIncreasePercent, "Default relative increase of a metric's mean, in percent, that is tolerated. For metrics where higher is better, like throughput, it applies to a decrease.")
+	fs.Float64Var(&o.maxAbsoluteIncreaseMs, "max-absolute-increase-ms", o.maxAbsoluteIncreaseMs, "Default absolute increase of a metric's mean, in milliseconds, that is tolerated. It only applies to metrics measured in milliseconds.")
+	fs.StringVar(&o.thresholdsFilePath, "thresholds", o.thresholdsFilePath, "Path to a YAML or JSON file with per-metric thresholds, overriding the defaults.")
+}
+
+func (o *compareOptions) validate() error {
+	var errs []error
+
+	if o.alpha <= 0 || o.alpha >= 1 {
+		errs = append(errs, fmt.Errorf("alpha %v must be in the (0, 1) interval", o.alpha))
+	}
+
+	if o.maxRelativeIncreasePercent < 0 {
+		errs = append(errs, fmt.Errorf("max-relative-increase-percent must not be negative"))
+	}
+
+	if o.maxAbsoluteIncreaseMs < 0 {
+		errs = append(errs, fmt.Errorf("max-absolute-increase-ms must not be negative"))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (o *compareOptions) includesMetric(metric string) bool {
+	if len(o.metrics) == 0 {
+		return true
+	}
+
+	return slices.Contains(strings.Split(o.metrics, ","), metric)
+}
+
+func (o *compareOptions) thresholds() (*Thresholds, error) {
+	thresholds := &Thresholds{}
+	if len(o.thresholdsFilePath) != 0 {
+		var err error
+		thresholds, err = readThresholdsFile(o.thresholdsFilePath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if thresholds.Default.RelativePercent == nil {
+		thresholds.Default.RelativePercent = ptr.To(o.maxRelativeIncreasePercent)
+	}
+
+	if thresholds.Default.AbsoluteMs == nil {
+		thresholds.Default.AbsoluteMs = ptr.To(o.maxAbsoluteIncreaseMs)
+	}
+
+	return thresholds, nil
+}
+
+type comparison struct {
+	group     string
+	metric    string
+	reference []float64
+	current   []float64
+	p         float64
+	verdict   verdict
+}
+
+// compareSampleGroups compares every metric of the reference groups with the same metric of the current groups.
+// A change for the worse, i.e. an increase or a decrease of metrics where higher is better, is a regression only if
+// it's statistically significant and exceeds both thresholds of the metric.
+// The absolute threshold is in milliseconds, so it's only applied to metrics measured in milliseconds.
+// Metrics with too few samples for any change to be significant are reported as such, instead of as insignificant.
+func compareSampleGroups(reference, current *sampleSet, thresholds *Thresholds, o *compareOptions) ([]comparison, error) {
+	var comparisons []comparison
+	for _, rg := range reference.groups {
+		cg := current.find(rg.name)
+		for _, metric := range rg.metrics {
+			if !o.includesMetric(metric) {
+				continue
+			}
+
+			c := comparison{
+				group:     rg.name,
+				metric:    metric,
+				reference: rg.values[metric],
+				p:         math.NaN(),
+			}
+			if cg != nil {
+				c.current = cg.values[metric]
+			}
+
+			if len(c.current) == 0 {
+				c.verdict = verdictMissing
+				comparisons = append(comparisons, c)
+				continue
+			}
+
+			res, err := stats.MannWhitneyUTest(c.reference, c.current)
+			if err != nil {
+				return nil, fmt.Errorf("can't compare metric %q of %q: %w", metric, rg.name, err)
+			}
+			c.p = res.P
+
+			referenceMean, currentMean := stats.Mean(c.reference), stats.Mean(c.current)
+			relativePercent, absoluteMs := thresholds.forMetric(metric)
+			description := rg.descriptions[metric]
+			if description.Unit != results.UnitMilliseconds {
+				absoluteMs = 0
+			}
+
+			worsening := currentMean - referenceMean
+			if description.HigherIsBetter {
+				worsening = -worsening
+			}
+
+			switch {
+			case stats.MannWhitneyUMinP(len(c.reference), len(c.current)) >= o.alpha:
+				c.verdict = verdictTooFewSamples
+			case c.p >= o.alpha:
+				c.verdict = verdictInsignificant
+			case worsening < 0:
+				c.verdict = verdictImprovement
+			case worsening > absoluteMs && worsening > math.Abs(referenceMean)*relativePercent/100:
+				c.verdict = verdictRegression
+			default:
+				c.verdict = verdictWithinThreshold
+			}
+
+			comparisons = append(comparisons, c)
+		}
+	}
+
+	return comparisons, nil
+}
+
+func runCompare(args []string, out io.Writer) error {
+	o := &compareOptions{
+		alpha:                      0.05,
+		maxRelativeIncreasePercent: 10,
+		maxAbsoluteIncreaseMs:      0,
+	}
+
+	fs := flag.NewFlagSet("compare", flag.ContinueOnError)
+	fs.SetOutput(out)
+	o.addFlags(fs)
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if fs.NArg() != 2 {
+		return fmt.Errorf("compare requires exactly two arguments, the reference and the current results, got %d", fs.NArg())
+	}
+	o.referencePath, o.currentPath = fs.Arg(0), fs.Arg(1)
+
+	err = o.validate()
+	if err != nil {
+		return err
+	}
+
+	thresholds, err := o.thresholds()
+	if err != nil {
+		return err
+	}
+
+	reference, err := loadSampleSet([]string{o.referencePath}, groupByScenario)
+	if err != nil {
+		return err
+	}
+
+	current, err := loadSampleSet([]string{o.currentPath}, groupByScenario)
+	if err != nil {
+		return err
+	}
+
+	comparisons, err := compareSampleGroups(reference, current, thresholds, o)
+	if err != nil {
+		return err
+	}
+
+	err = printComparisons(out, comparisons)
+	if err != nil {
+		return err
+	}
+
+	regressions, tooFewSamples := 0, 0
+	for _, c := range comparisons {
+		switch c.verdict {
+		case verdictRegression:
+			regressions++
+		case verdictTooFewSamples:
+			tooFewSamples++
+		}
+	}
+
+	if tooFewSamples != 0 {
+		_, err = fmt.Fprintf(out, "\nWARNING: %d metric(s) have too few samples for a change to be significant at alpha %v.\n", tooFewSamples, o.alpha)
+		if err != nil {
+			return err
+		}
+	}
+
+	if regressions != 0 {
+		return fmt.Errorf("found %d statistically significant regression(s)", regressions)
+	}
+
+	return nil
+}
+
+func printComparisons(out io.Writer, comparisons []comparison) error {
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, err := fmt.Fprintf(tw, "SCENARIO\tMETRIC\tREFERENCE\tCURRENT\tDELTA\tVERDICT\n")
+	if err != nil {
+		return err
+	}
+
+	for _, c := range comparisons {
+		referenceMean, currentMean := stats.Mean(c.reference), stats.Mean(c.current)
+
+		delta := "~"
+		if len(c.current) != 0 {
+			delta = fmt.Sprintf("%s (p=%.3f n=%d+%d)", formatRelativeDelta(referenceMean, currentMean), c.p, len(c.reference), len(c.current))
+		}
+
+		_, err = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", c.group, c.metric, formatMeanWithVariation(c.reference), formatMeanWithVariation(c.current), delta, c.verdict)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tw.Flush()
+}
+
+// formatMeanWithVariation formats the mean with the coefficient of variation, like benchstat does.
+func formatMeanWithVariation(values []float64) string {
+	if len(values) == 0 {
+		return "-"
+	}
+
+	mean := stats.Mean(values)
+	if mean == 0 {
+		return fmt.Sprintf("%.1f", mean)
+	}
+
+	return fmt.Sprintf("%.1f ±%.0f%%", mean, stats.StdDev(values)/math.Abs(mean)*100)
+}
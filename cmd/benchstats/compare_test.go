@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRunCompare(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name          string
+		args          []string
+		expectedLines []string
+		expectedErr   string
+	}{
+		{
+			name: "identical results pass",
+			args: []string{"testdata/compare/reference", "testdata/compare/reference"},
+			expectedLines: []string{
+				`^cold\s+elapsed_time_ms\s+44380.0 ±8%\s+44380.0 ±8%\s+\+0.00% \(p=1.000 n=10\+10\)\s+~$`,
+			},
+		},
+		{
+			name: "significant increase beyond default thresholds fails",
+			args: []string{"testdata/compare/reference", "testdata/compare/current"},
+			expectedLines: []string{
+				`^cold\s+elapsed_time_ms\s+44380.0 ±8%\s+50161.9 ±8%\s+\+13.03% \(p=0.015 n=10\+10\)\s+REGRESSION$`,
+				`^cold\s+application_time_ms\s.*~$`,
+				`^cold\s+overhead_time_ms\s.*\+25.00%.*REGRESSION$`,
+			},
+			expectedErr: "found 2 statistically significant regression(s)",
+		},
+		{
+			name: "per-metric thresholds tolerate the increase",
+			args: []string{"-thresholds=testdata/compare/thresholds.yaml", "testdata/compare/reference", "testdata/compare/current"},
+			expectedLines: []string{
+				`^cold\s+elapsed_time_ms\s.*within threshold$`,
+				`^cold\s+overhead_time_ms\s.*within threshold$`,
+			},
+		},
+		{
+			name: "absolute threshold tolerates the increase",
+			args: []string{"-max-absolute-increase-ms=10000", "testdata/compare/reference", "testdata/compare/current"},
+			expectedLines: []string{
+				`^cold\s+elapsed_time_ms\s.*within threshold$`,
+			},
+		},
+		{
+			name: "improvement passes",
+			args: []string{"testdata/compare/current", "testdata/compare/reference"},
+			expectedLines: []string{
+				`^cold\s+overhead_time_ms\s.*improvement$`,
+			},
+		},
+		{
+			name: "throughput increase is an improvement",
+			args: []string{"testdata/compare/steady-state/reference", "testdata/compare/steady-state/current"},
+			expectedLines: []string{
+				`^unpause\s+steady_state/baseline_throughput\s.*\+20.31%.*improvement$`,
+			},
+			expectedErr: "found 2 statistically significant regression(s)",
+		},
+		{
+			name: "throughput decrease is a regression",
+			args: []string{"testdata/compare/steady-state/current", "testdata/compare/steady-state/reference"},
+			expectedLines: []string{
+				`^unpause\s+steady_state/baseline_throughput\s.*-16.88%.*REGRESSION$`,
+				`^unpause\s+resources/memory_working_set_bytes\s.*improvement$`,
+			},
+			expectedErr: "found 1 statistically significant regression(s)",
+		},
+		{
+			name: "absolute threshold in milliseconds doesn't apply to other units",
+			args: []string{"-max-absolute-increase-ms=1000000000", "testdata/compare/steady-state/reference", "testdata/compare/steady-state/current"},
+			expectedLines: []string{
+				`^unpause\s+resources/memory_working_set_bytes\s.*\+30.00%.*REGRESSION$`,
+				`^unpause\s+resources/max_memory_working_set_bytes\s.*REGRESSION$`,
+			},
+			expectedErr: "found 2 statistically significant regression(s)",
+		},
+		{
+			name: "scenario missing from current results is reported",
+			args: []string{"testdata", "testdata/compare/reference"},
+			expectedLines: []string{
+				`^prewarmed\s+elapsed_time_ms\s+24508.4 ±10%\s+-\s+~\s+missing$`,
+			},
+		},
+		{
+			name: "samples too small to be significant are reported",
+			args: []string{"testdata/compare/few-samples/reference", "testdata/compare/few-samples/current"},
+			expectedLines: []string{
+				`^cold\s+elapsed_time_ms\s.*\(p=0.100 n=3\+3\)\s+too few samples$`,
+				`^WARNING: 1 metric\(s\) have too few samples for a change to be significant at alpha 0.05.$`,
+			},
+		},
+		{
+			name: "samples too small to be significant at the default alpha can be at a higher one",
+			args: []string{"-alpha=0.2", "testdata/compare/few-samples/reference", "testdata/compare/few-samples/current"},
+			expectedLines: []string{
+				`^cold\s+elapsed_time_ms\s.*\(p=0.100 n=3\+3\)\s+REGRESSION$`,
+			},
+			expectedErr: "found 1 statistically significant regression(s)",
+		},
+		{
+			name:        "requires two paths",
+			args:        []string{"testdata/compare/reference"},
+			expectedErr: "compare requires exactly two arguments, the reference and the current results, got 1",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			out := &bytes.Buffer{}
+			err := run(append([]string{"compare"}, tc.args...), out)
+			if len(tc.expectedErr) == 0 && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(tc.expectedErr) != 0 && (err == nil || err.Error() != tc.expectedErr) {
+				t.Fatalf("expected error %q, got %v", tc.expectedErr, err)
+			}
+
+			lines := strings.Split(out.String(), "\n")
+			for _, expected := range tc.expectedLines {
+				if !containsMatchingLine(lines, expected) {
+					t.Errorf("expected a line matching %q in output:\n%s", expected, out.String())
+				}
+			}
+		})
+	}
+}
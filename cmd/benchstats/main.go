@@ -0,0 +1,15 @@
+// benchstats summarizes and compares benchmark result files written to --dest-dir by the benchmark suites.
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	err := run(os.Args[1:], os.Stdout)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/pausing-clusters-thesis/benchmarks/results"
+)
+
+const (
+	groupByScenario = "scenario"
+	groupByFile     = "file"
+)
+
+var skippedResultFileExtensions = []string{
+	".csv",
+	".prom",
+}
+
+var supportedGroupBys = []string{
+	groupByScenario,
+	groupByFile,
+}
+
+// sampleGroup holds the values of all metrics of records that belong to the same group, e.g. the same scenario.
+type sampleGroup struct {
+	name    string
+	metrics []string
+	values  map[string][]float64
+	// descriptions hold the unit and direction of every metric, with the value of its first sample.
+	descriptions map[string]results.Metric
+}
+
+func (sg *sampleGroup) add(record *results.Record) {
+	for _, m := range record.Metrics() {
+		if _, ok := sg.values[m.Name]; !ok {
+			sg.metrics = append(sg.metrics, m.Name)
+			sg.descriptions[m.Name] = m
+		}
+		sg.values[m.Name] = append(sg.values[m.Name], m.Value)
+	}
+}
+
+type sampleSet struct {
+	groups []*sampleGroup
+}
+
+func (ss *sampleSet) find(name string) *sampleGroup {
+	idx := slices.IndexFunc(ss.groups, func(sg *sampleGroup) bool {
+		return sg.name == name
+	})
+	if idx < 0 {
+		return nil
+	}
+
+	return ss.groups[idx]
+}
+
+func (ss *sampleSet) group(name string) *sampleGroup {
+	sg := ss.find(name)
+	if sg != nil {
+		return sg
+	}
+
+	sg = &sampleGroup{
+		name:         name,
+		values:       map[string][]float64{},
+		descriptions: map[string]results.Metric{},
+	}
+	ss.groups = append(ss.groups, sg)
+
+	return sg
+}
+
+func loadSampleSet(filePaths []string, groupBy string) (*sampleSet, error) {
+	if !slices.Contains(supportedGroupBys, groupBy) {
+		return nil, fmt.Errorf("unsupported group-by %q, supported values are: %v", groupBy, supportedGroupBys)
+	}
+
+	filePaths, err := expandResultPaths(filePaths)
+	if err != nil {
+		return nil, err
+	}
+
+	ss := &sampleSet{}
+	for _, filePath := range filePaths {
+		records, err := results.ReadRecordsFile(filePath)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, record := range records {
+			var groupName string
+			switch groupBy {
+			case groupByScenario:
+				groupName = record.Scenario
+			case groupByFile:
+				groupName = filepath.Clean(filePath)
+			}
+
+			ss.group(groupName).add(record)
+		}
+	}
+
+	return ss, nil
+}
+
+// expandResultPaths replaces directories with the JSON lines results files they contain.
+// Hidden files, subdirectories and results saved in other formats are skipped.
+func expandResultPaths(paths []string) ([]string, error) {
+	var filePaths []string
+	for _, p := range paths {
+		fi, err := os.Stat(p)
+		if err != nil {
+			return nil, fmt.Errorf("can't stat %q: %w", p, err)
+		}
+
+		if !fi.IsDir() {
+			filePaths = append(filePaths, p)
+			continue
+		}
+
+		entries, err := os.ReadDir(p)
+		if err != nil {
+			return nil, fmt.Errorf("can't read directory %q: %w", p, err)
+		}
+
+		for _, entry := range entries {
+			if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") || slices.Contains(skippedResultFileExtensions, filepath.Ext(entry.Name())) {
+				continue
+			}
+
+			filePaths = append(filePaths, filepath.Join(p, entry.Name()))
+		}
+	}
+
+	return filePaths, nil
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pausing-clusters-thesis/benchmarks/stats"
+)
+
+type summarizeOptions struct {
+	groupBy             string
+	metrics             string
+	confidence          float64
+	bootstrapIterations int
+	seed                uint64
+	alpha               float64
+
+	filePaths []string
+}
+
+func (o *summarizeOptions) addFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.groupBy, "group-by", o.groupBy, fmt.Sprintf("How to group records. Supported values are: %v.", supportedGroupBys))
+	fs.StringVar(&o.metrics, "metrics", o.metrics, "Comma-separated list of metrics to report. All metrics are reported when empty.")
+	fs.Float64Var(&o.confidence, "confidence", o.confidence, "Confidence level of bootstrap confidence intervals.")
+	fs.IntVar(&o.bootstrapIterations, "bootstrap-iterations", o.bootstrapIterations, "Number of bootstrap resamples used to compute confidence intervals.")
+	fs.Uint64Var(&o.seed, "seed", o.seed, "Seed of the bootstrap random number generator.")
+	fs.Float64Var(&o.alpha, "alpha", o.alpha, "Significance level of pairwise tests.")
+}
+
+func (o *summarizeOptions) validate() error {
+	var errs []error
+
+	if len(o.filePaths) == 0 {
+		errs = append(errs, errors.New("at least one results file is required"))
+	}
+
+	if o.confidence <= 0 || o.confidence >= 1 {
+		errs = append(errs, fmt.Errorf("confidence %v must be in the (0, 1) interval", o.confidence))
+	}
+
+	if o.bootstrapIterations <= 0 {
+		errs = append(errs, fmt.Errorf("bootstrap-iterations must be greater than zero"))
+	}
+
+	if o.alpha <= 0 || o.alpha >= 1 {
+		errs = append(errs, fmt.Errorf("alpha %v must be in the (0, 1) interval", o.alpha))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (o *summarizeOptions) includesMetric(metric string) bool {
+	if len(o.metrics) == 0 {
+		return true
+	}
+
+	return slices.Contains(strings.Split(o.metrics, ","), metric)
+}
+
+func runSummarize(args []string, out io.Writer) error {
+	o := &summarizeOptions{
+		groupBy:             groupByScenario,
+		confidence:          0.95,
+		bootstrapIterations: 10000,
+		seed:                1,
+		alpha:               0.05,
+	}
+
+	fs := flag.NewFlagSet("summarize", flag.ContinueOnError)
+	fs.SetOutput(out)
+	o.addFlags(fs)
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+	o.filePaths = fs.Args()
+
+	err = o.validate()
+	if err != nil {
+		return err
+	}
+
+	ss, err := loadSampleSet(o.filePaths, o.groupBy)
+	if err != nil {
+		return err
+	}
+
+	err = printSummaries(out, ss, o)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(out)
+	if err != nil {
+		return err
+	}
+
+	return printPairwiseTests(out, ss, o)
+}
+
+func printSummaries(out io.Writer, ss *sampleSet, o *summarizeOptions) error {
+	rng := rand.New(rand.NewPCG(o.seed, o.seed))
+	ciHeader := fmt.Sprintf("CI%g", o.confidence*100)
+
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, err := fmt.Fprintf(tw, "GROUP\tMETRIC\tN\tMEAN\tSTDDEV\tMIN\tMAX\tP50\tP90\tP95\tP99\t%s\n", ciHeader)
+	if err != nil {
+		return err
+	}
+
+	for _, sg := range ss.groups {
+		for _, metric := range sg.metrics {
+			if !o.includesMetric(metric) {
+				continue
+			}
+
+			values := sg.values[metric]
+			s := stats.Summarize(values)
+			ciLow, ciHigh := stats.BootstrapMeanCI(values, o.confidence, o.bootstrapIterations, rng)
+
+			_, err = fmt.Fprintf(tw, "%s\t%s\t%d\t%.1f\t%.1f\t%.1f\t%.1f\t%.1f\t%.1f\t%.1f\t%.1f\t[%.1f, %.1f]\n",
+				sg.name, metric, s.N, s.Mean, s.StdDev, s.Min, s.Max, s.P50, s.P90, s.P95, s.P99, ciLow, ciHigh,
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return tw.Flush()
+}
+
+func printPairwiseTests(out io.Writer, ss *sampleSet, o *summarizeOptions) error {
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, err := fmt.Fprintf(tw, "METRIC\tA\tB\tMEAN A\tMEAN B\tDELTA\tU\tP-VALUE\tSIGNIFICANT\n")
+	if err != nil {
+		return err
+	}
+
+	for i, a := range ss.groups {
+		for _, b := range ss.groups[i+1:] {
+			for _, metric := range a.metrics {
+				if !o.includesMetric(metric) {
+					continue
+				}
+
+				aValues, bValues := a.values[metric], b.values[metric]
+				if len(bValues) == 0 {
+					continue
+				}
+
+				res, err := stats.MannWhitneyUTest(aValues, bValues)
+				if err != nil {
+					return fmt.Errorf("can't compare metric %q of %q and %q: %w", metric, a.name, b.name, err)
+				}
+
+				aMean, bMean := stats.Mean(aValues), stats.Mean(bValues)
+				_, err = fmt.Fprintf(tw, "%s\t%s\t%s\t%.1f\t%.1f\t%s\t%.1f\t%.4f\t%t\n",
+					metric, a.name, b.name, aMean, bMean, formatRelativeDelta(aMean, bMean), res.U, res.P, res.P < o.alpha,
+				)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return tw.Flush()
+}
+
+func formatRelativeDelta(old, new float64) string {
+	if old == 0 {
+		return "~"
+	}
+
+	delta := (new - old) / math.Abs(old) * 100
+	return fmt.Sprintf("%+.2f%%", delta)
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestRunSummarize(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name            string
+		args            []string
+		expectedLines   []string
+		unexpectedLines []string
+		expectedErr     bool
+	}{
+		{
+			name: "summarizes legacy and versioned records",
+			args: []string{"testdata/prewarmed", "testdata/cold", "testdata/baseline"},
+			expectedLines: []string{
+				`^prewarmed\s+elapsed_time_ms\s+10\s+24508.4\s+2485.0\s+20501.0\s+26654.0\s+25870.0\s`,
+				`^cold\s+overhead_time_ms\s+10\s+23130.6\s`,
+				`^baseline\s+phase/pods-scheduled_ms\s+5\s+1020.0\s+15.8\s+1000.0\s+1040.0\s`,
+				`^elapsed_time_ms\s+prewarmed\s+cold\s+24508.4\s+44380.0\s+\+81.08%\s+0.0\s+0.0000\s+true$`,
+				`^application_time_ms\s+prewarmed\s+cold\s+20356.0\s+21249.0\s+\+4.39%\s+41.0\s+0.5288\s+false$`,
+			},
+		},
+		{
+			name: "reports only selected metrics",
+			args: []string{"-metrics=overhead_time_ms", "testdata/prewarmed", "testdata/cold"},
+			expectedLines: []string{
+				`^prewarmed\s+overhead_time_ms\s`,
+				`^overhead_time_ms\s+prewarmed\s+cold\s`,
+			},
+			unexpectedLines: []string{
+				`elapsed_time_ms`,
+				`application_time_ms`,
+			},
+		},
+		{
+			name: "groups by file",
+			args: []string{"-group-by=file", "testdata/cold"},
+			expectedLines: []string{
+				`^testdata/cold\s+elapsed_time_ms\s+10\s`,
+			},
+		},
+		{
+			name:        "fails without files",
+			args:        []string{},
+			expectedErr: true,
+		},
+		{
+			name:        "fails on missing file",
+			args:        []string{"testdata/missing"},
+			expectedErr: true,
+		},
+		{
+			name:        "fails on invalid confidence",
+			args:        []string{"-confidence=1.5", "testdata/cold"},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			out := &bytes.Buffer{}
+			err := run(append([]string{"summarize"}, tc.args...), out)
+			if tc.expectedErr != (err != nil) {
+				t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+			}
+
+			lines := strings.Split(out.String(), "\n")
+			for _, expected := range tc.expectedLines {
+				if !containsMatchingLine(lines, expected) {
+					t.Errorf("expected a line matching %q in output:\n%s", expected, out.String())
+				}
+			}
+
+			for _, unexpected := range tc.unexpectedLines {
+				if containsMatchingLine(lines, unexpected) {
+					t.Errorf("expected no line matching %q in output:\n%s", unexpected, out.String())
+				}
+			}
+		})
+	}
+}
+
+func containsMatchingLine(lines []string, pattern string) bool {
+	re := regexp.MustCompile(pattern)
+	for _, line := range lines {
+		if re.MatchString(line) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Threshold defines how much a metric may grow, or drop when higher is better, before it's considered a regression.
+// Both thresholds have to be exceeded, so that small absolute changes of short phases don't fail the comparison.
+// AbsoluteMs only applies to metrics measured in milliseconds.
+type Threshold struct {
+	RelativePercent *float64 `json:"relativePercent,omitempty"`
+	AbsoluteMs      *float64 `json:"absoluteMs,omitempty"`
+}
+
+type Thresholds struct {
+	Default Threshold            `json:"default"`
+	Metrics map[string]Threshold `json:"metrics,omitempty"`
+}
+
+func readThresholdsFile(filePath string) (*Thresholds, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("can't read thresholds file %q: %w", filePath, err)
+	}
+
+	thresholds := &Thresholds{}
+	err = yaml.UnmarshalStrict(data, thresholds)
+	if err != nil {
+		return nil, fmt.Errorf("can't decode thresholds file %q: %w", filePath, err)
+	}
+
+	return thresholds, nil
+}
+
+// forMetric returns the thresholds of the metric, with unset values taken from the defaults.
+func (t *Thresholds) forMetric(metric string) (float64, float64) {
+	relativePercent, absoluteMs := 0.0, 0.0
+	if t.Default.RelativePercent != nil {
+		relativePercent = *t.Default.RelativePercent
+	}
+	if t.Default.AbsoluteMs != nil {
+		absoluteMs = *t.Default.AbsoluteMs
+	}
+
+	mt, ok := t.Metrics[metric]
+	if ok {
+		if mt.RelativePercent != nil {
+			relativePercent = *mt.RelativePercent
+		}
+		if mt.AbsoluteMs != nil {
+			absoluteMs = *mt.AbsoluteMs
+		}
+	}
+
+	return relativePercent, absoluteMs
+}
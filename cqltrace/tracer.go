@@ -0,0 +1,214 @@
+package cqltrace
+
+import (
+	"cmp"
+	"context"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+// Interval is a time range of a single operation.
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+func (i *Interval) Duration() time.Duration {
+	return i.End.Sub(i.Start)
+}
+
+// Dial is the time it took to set up the transport of a connection, before the CQL handshake.
+type Dial struct {
+	Interval
+	// TCP is the time it took to open the TCP connection, to the ingress when connecting through one.
+	TCP Interval
+}
+
+// TLSDuration returns the time from opening the TCP connection to completing the TLS handshake.
+// It includes SNI routing at the ingress, which dials the node only once it knows the server name.
+func (d *Dial) TLSDuration() time.Duration {
+	return d.End.Sub(d.TCP.End)
+}
+
+// HostTimings are timings of the first successful connection to a host and of the first successful query it served.
+// Connections to initial contact points are made before host IDs are known, so they have no host ID.
+type HostTimings struct {
+	HostID          string
+	Address         string
+	ConnectAttempts int
+	FailedConnects  int
+	FirstDial       *Dial
+	// FirstConnect spans the dial and the CQL handshake.
+	FirstConnect *Interval
+	FirstQuery   *Interval
+}
+
+// Tracer collects HostTimings of sessions created from a ClusterConfig it instrumented.
+// A session opens several connections to every host, one per shard, so the first dial and the first connect
+// of a host don't have to belong to the same connection.
+type Tracer struct {
+	lock  sync.Mutex
+	hosts map[string]*HostTimings
+}
+
+var (
+	_ gocql.ConnectObserver = &Tracer{}
+	_ gocql.QueryObserver   = &Tracer{}
+)
+
+func NewTracer() *Tracer {
+	return &Tracer{
+		hosts: map[string]*HostTimings{},
+	}
+}
+
+func hostKey(host *gocql.HostInfo) string {
+	if len(host.HostID()) != 0 {
+		return host.HostID()
+	}
+
+	return host.HostnameAndPort()
+}
+
+// hostLocked has to be called with the lock held.
+func (t *Tracer) hostLocked(host *gocql.HostInfo) *HostTimings {
+	key := hostKey(host)
+	ht, ok := t.hosts[key]
+	if !ok {
+		ht = &HostTimings{
+			HostID:  host.HostID(),
+			Address: host.HostnameAndPort(),
+		}
+		t.hosts[key] = ht
+	}
+
+	return ht
+}
+
+// Instrument makes sessions created from the cluster report to the tracer. hostDialer has to dial through a dialer
+// returned by Dialer for TCP and TLS to be timed separately.
+func (t *Tracer) Instrument(cluster *gocql.ClusterConfig, hostDialer gocql.HostDialer) {
+	cluster.HostDialer = t.HostDialer(hostDialer)
+	cluster.ConnectObserver = t
+	cluster.QueryObserver = t
+}
+
+type dialContextKey struct{}
+
+// Dialer returns a dialer that times TCP connections opened on behalf of a HostDialer returned by HostDialer.
+func (t *Tracer) Dialer(dialer gocql.Dialer) gocql.Dialer {
+	return &tracingDialer{dialer: dialer}
+}
+
+type tracingDialer struct {
+	dialer gocql.Dialer
+}
+
+func (d *tracingDialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	start := time.Now()
+	conn, err := d.dialer.DialContext(ctx, network, addr)
+	end := time.Now()
+
+	// Only the first TCP connection is timed if a proxy dials more than once.
+	dial, ok := ctx.Value(dialContextKey{}).(*Dial)
+	if ok && dial.TCP.Start.IsZero() {
+		dial.TCP = Interval{Start: start, End: end}
+	}
+
+	return conn, err
+}
+
+// HostDialer returns a HostDialer that times dialing the hosts.
+func (t *Tracer) HostDialer(hostDialer gocql.HostDialer) gocql.HostDialer {
+	return &tracingHostDialer{
+		tracer:     t,
+		hostDialer: hostDialer,
+	}
+}
+
+type tracingHostDialer struct {
+	tracer     *Tracer
+	hostDialer gocql.HostDialer
+}
+
+func (d *tracingHostDialer) DialHost(ctx context.Context, host *gocql.HostInfo) (*gocql.DialedHost, error) {
+	dial := &Dial{}
+	dial.Start = time.Now()
+	dialedHost, err := d.hostDialer.DialHost(context.WithValue(ctx, dialContextKey{}, dial), host)
+	dial.End = time.Now()
+	if err != nil {
+		return dialedHost, err
+	}
+
+	d.tracer.lock.Lock()
+	defer d.tracer.lock.Unlock()
+
+	ht := d.tracer.hostLocked(host)
+	if ht.FirstDial == nil {
+		ht.FirstDial = dial
+	}
+
+	return dialedHost, nil
+}
+
+func (t *Tracer) ObserveConnect(oc gocql.ObservedConnect) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	ht := t.hostLocked(oc.Host)
+	ht.ConnectAttempts++
+	if oc.Err != nil {
+		ht.FailedConnects++
+		return
+	}
+
+	if ht.FirstConnect == nil {
+		ht.FirstConnect = &Interval{Start: oc.Start, End: oc.End}
+	}
+}
+
+func (t *Tracer) ObserveQuery(_ context.Context, oq gocql.ObservedQuery) {
+	if oq.Err != nil || oq.Host == nil {
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	ht := t.hostLocked(oq.Host)
+	if ht.FirstQuery == nil {
+		ht.FirstQuery = &Interval{Start: oq.Start, End: oq.End}
+	}
+}
+
+// Hosts returns copies of the timings of all hosts in the order their first connection was made.
+// Hosts that were never connected to come last.
+func (t *Tracer) Hosts() []HostTimings {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	hosts := make([]HostTimings, 0, len(t.hosts))
+	for _, ht := range t.hosts {
+		hosts = append(hosts, *ht)
+	}
+
+	slices.SortFunc(hosts, func(a, b HostTimings) int {
+		switch {
+		case a.FirstConnect == nil && b.FirstConnect == nil:
+			return strings.Compare(a.Address, b.Address)
+		case a.FirstConnect == nil:
+			return 1
+		case b.FirstConnect == nil:
+			return -1
+		default:
+			return cmp.Or(a.FirstConnect.End.Compare(b.FirstConnect.End), strings.Compare(a.Address, b.Address))
+		}
+	})
+
+	return hosts
+}
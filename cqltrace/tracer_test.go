@@ -0,0 +1,156 @@
+package cqltrace
+
+import (
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+type fakeDialer struct {
+	delay time.Duration
+}
+
+func (d *fakeDialer) DialContext(_ context.Context, _, _ string) (net.Conn, error) {
+	time.Sleep(d.delay)
+	client, server := net.Pipe()
+	_ = server.Close()
+	return client, nil
+}
+
+// fakeHostDialer dials the TCP connection and then pretends to make a TLS handshake.
+type fakeHostDialer struct {
+	dialer   gocql.Dialer
+	tlsDelay time.Duration
+	err      error
+}
+
+func (d *fakeHostDialer) DialHost(ctx context.Context, host *gocql.HostInfo) (*gocql.DialedHost, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	conn, err := d.dialer.DialContext(ctx, "tcp", host.HostnameAndPort())
+	if err != nil {
+		return nil, err
+	}
+	time.Sleep(d.tlsDelay)
+
+	return &gocql.DialedHost{Conn: conn}, nil
+}
+
+func newHost(hostID string, address string) *gocql.HostInfo {
+	host := &gocql.HostInfo{}
+	host.SetHostID(hostID)
+	host.SetConnectAddress(net.ParseIP(address))
+	return host
+}
+
+func TestTracerDial(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewTracer()
+	hostDialer := tracer.HostDialer(&fakeHostDialer{
+		dialer:   tracer.Dialer(&fakeDialer{delay: 10 * time.Millisecond}),
+		tlsDelay: 20 * time.Millisecond,
+	})
+
+	host := newHost("a", "10.0.0.1")
+	dialedHost, err := hostDialer.DialHost(context.Background(), host)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = dialedHost.Conn.Close()
+
+	hosts := tracer.Hosts()
+	if len(hosts) != 1 {
+		t.Fatalf("expected timings of 1 host, got %d", len(hosts))
+	}
+
+	dial := hosts[0].FirstDial
+	if dial == nil {
+		t.Fatal("expected the first dial to be recorded")
+	}
+
+	if dial.TCP.Duration() < 10*time.Millisecond {
+		t.Errorf("expected TCP to take at least 10ms, got %v", dial.TCP.Duration())
+	}
+
+	if dial.TLSDuration() < 20*time.Millisecond {
+		t.Errorf("expected TLS to take at least 20ms, got %v", dial.TLSDuration())
+	}
+
+	if dial.Duration() < dial.TCP.Duration()+dial.TLSDuration() {
+		t.Errorf("expected the dial of %v to span TCP %v and TLS %v", dial.Duration(), dial.TCP.Duration(), dial.TLSDuration())
+	}
+}
+
+func TestTracerFailedDial(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewTracer()
+	hostDialer := tracer.HostDialer(&fakeHostDialer{
+		err: errors.New("connection refused"),
+	})
+
+	_, err := hostDialer.DialHost(context.Background(), newHost("a", "10.0.0.1"))
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+
+	if hosts := tracer.Hosts(); len(hosts) != 0 {
+		t.Errorf("expected no timings of failed dials, got %v", hosts)
+	}
+}
+
+func TestTracerObserve(t *testing.T) {
+	t.Parallel()
+
+	startTime := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	at := func(ms int) time.Time {
+		return startTime.Add(time.Duration(ms) * time.Millisecond)
+	}
+
+	a := newHost("a", "10.0.0.1")
+	b := newHost("b", "10.0.0.2")
+	contactPoint := newHost("", "10.0.0.3")
+
+	tracer := NewTracer()
+	tracer.ObserveConnect(gocql.ObservedConnect{Host: contactPoint, Start: at(0), End: at(100)})
+	tracer.ObserveConnect(gocql.ObservedConnect{Host: b, Start: at(100), End: at(150), Err: errors.New("connection refused")})
+	tracer.ObserveConnect(gocql.ObservedConnect{Host: a, Start: at(100), End: at(300)})
+	tracer.ObserveConnect(gocql.ObservedConnect{Host: b, Start: at(150), End: at(200)})
+	tracer.ObserveConnect(gocql.ObservedConnect{Host: a, Start: at(300), End: at(400)})
+	tracer.ObserveQuery(context.Background(), gocql.ObservedQuery{Host: a, Start: at(400), End: at(450), Err: errors.New("timeout")})
+	tracer.ObserveQuery(context.Background(), gocql.ObservedQuery{Host: a, Start: at(450), End: at(500)})
+	tracer.ObserveQuery(context.Background(), gocql.ObservedQuery{Host: a, Start: at(500), End: at(510)})
+
+	expected := []HostTimings{
+		{
+			Address:         contactPoint.HostnameAndPort(),
+			ConnectAttempts: 1,
+			FirstConnect:    &Interval{Start: at(0), End: at(100)},
+		},
+		{
+			HostID:          "b",
+			Address:         b.HostnameAndPort(),
+			ConnectAttempts: 2,
+			FailedConnects:  1,
+			FirstConnect:    &Interval{Start: at(150), End: at(200)},
+		},
+		{
+			HostID:          "a",
+			Address:         a.HostnameAndPort(),
+			ConnectAttempts: 2,
+			FirstConnect:    &Interval{Start: at(100), End: at(300)},
+			FirstQuery:      &Interval{Start: at(450), End: at(500)},
+		},
+	}
+	if got := tracer.Hosts(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected hosts %#v, got %#v", expected, got)
+	}
+}
@@ -0,0 +1,295 @@
+package integrity
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+const (
+	DefaultKeyspace         = "integrity"
+	DefaultRowsPerPartition = 16
+	DefaultRowSizeBytes     = 1024
+	DefaultConcurrency      = 32
+
+	tableName = "partitions"
+)
+
+// Options describe the data written to verify its integrity after the cluster is unpaused.
+// Partitions are identified by consecutive numbers starting at zero.
+type Options struct {
+	Keyspace          string
+	Partitions        int64
+	RowsPerPartition  int
+	RowSizeBytes      int
+	Concurrency       int
+	ReplicationFactor int
+	// Consistency is used both to write and to read the data.
+	Consistency gocql.Consistency
+	Seed        uint64
+}
+
+func (o *Options) Validate() error {
+	var errs []error
+
+	if len(o.Keyspace) == 0 {
+		errs = append(errs, errors.New("keyspace must not be empty"))
+	}
+
+	if o.Partitions <= 0 {
+		errs = append(errs, fmt.Errorf("partitions must be greater than zero, got %d", o.Partitions))
+	}
+
+	if o.RowsPerPartition <= 0 {
+		errs = append(errs, fmt.Errorf("rows per partition must be greater than zero, got %d", o.RowsPerPartition))
+	}
+
+	if o.RowSizeBytes <= 0 {
+		errs = append(errs, fmt.Errorf("row size must be greater than zero, got %d", o.RowSizeBytes))
+	}
+
+	if o.Concurrency <= 0 {
+		errs = append(errs, fmt.Errorf("concurrency must be greater than zero, got %d", o.Concurrency))
+	}
+
+	if o.ReplicationFactor <= 0 {
+		errs = append(errs, fmt.Errorf("replication factor must be greater than zero, got %d", o.ReplicationFactor))
+	}
+
+	if o.Consistency == gocql.Any {
+		errs = append(errs, fmt.Errorf("consistency %v can't be used to read", o.Consistency))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Value returns the deterministic content of a row.
+func Value(seed uint64, partition int64, row int, size int) []byte {
+	r := rand.New(rand.NewPCG(seed^uint64(partition), uint64(row)))
+
+	value := make([]byte, size)
+	for i := 0; i < size; i += 8 {
+		var buf [8]byte
+		binary.LittleEndian.PutUint64(buf[:], r.Uint64())
+		copy(value[i:], buf[:])
+	}
+
+	return value
+}
+
+type Checksum [sha256.Size]byte
+
+func (c Checksum) String() string {
+	return fmt.Sprintf("%x", c[:])
+}
+
+// checksummer computes the checksum of a partition out of its rows in clustering order.
+type checksummer struct {
+	buf bytes.Buffer
+}
+
+func (c *checksummer) add(row int, value []byte) {
+	c.buf.Write(binary.LittleEndian.AppendUint32(nil, uint32(row)))
+	c.buf.Write(binary.LittleEndian.AppendUint32(nil, uint32(len(value))))
+	c.buf.Write(value)
+}
+
+func (c *checksummer) sum() Checksum {
+	return sha256.Sum256(c.buf.Bytes())
+}
+
+// Manifest holds checksums of the written partitions, indexed by partition.
+type Manifest struct {
+	Options   Options
+	Checksums []Checksum
+	Duration  time.Duration
+}
+
+// forEachPartition calls f concurrently for all partitions, stopping at the first error.
+func forEachPartition(ctx context.Context, partitions []int64, concurrency int, f func(context.Context, int64) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	partitionCh := make(chan int64)
+	go func() {
+		defer close(partitionCh)
+		for _, p := range partitions {
+			select {
+			case partitionCh <- p:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var errOnce sync.Once
+	var firstErr error
+	var wg sync.WaitGroup
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for p := range partitionCh {
+				err := f(ctx, p)
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	return ctx.Err()
+}
+
+// Write creates the keyspace and table, writes all partitions and returns their checksums.
+func Write(ctx context.Context, session *gocql.Session, options Options) (*Manifest, error) {
+	err := options.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid integrity options: %w", err)
+	}
+
+	startTime := time.Now()
+
+	err = session.Query(fmt.Sprintf(
+		`CREATE KEYSPACE IF NOT EXISTS %q WITH replication = {'class': 'NetworkTopologyStrategy', 'replication_factor': %d}`,
+		options.Keyspace,
+		options.ReplicationFactor,
+	)).WithContext(ctx).Exec()
+	if err != nil {
+		return nil, fmt.Errorf("can't create keyspace %q: %w", options.Keyspace, err)
+	}
+
+	err = session.Query(fmt.Sprintf(
+		`CREATE TABLE IF NOT EXISTS %q.%q (partition bigint, row int, value blob, PRIMARY KEY (partition, row))`,
+		options.Keyspace,
+		tableName,
+	)).WithContext(ctx).Exec()
+	if err != nil {
+		return nil, fmt.Errorf("can't create table %q: %w", tableName, err)
+	}
+
+	partitions := make([]int64, 0, options.Partitions)
+	for p := range options.Partitions {
+		partitions = append(partitions, p)
+	}
+
+	checksums := make([]Checksum, options.Partitions)
+	err = forEachPartition(ctx, partitions, options.Concurrency, func(ctx context.Context, partition int64) error {
+		var c checksummer
+		for row := range options.RowsPerPartition {
+			value := Value(options.Seed, partition, row, options.RowSizeBytes)
+			err := session.Query(
+				fmt.Sprintf(`INSERT INTO %q.%q (partition, row, value) VALUES (?, ?, ?)`, options.Keyspace, tableName),
+				partition,
+				row,
+				value,
+			).WithContext(ctx).Consistency(options.Consistency).Exec()
+			if err != nil {
+				return fmt.Errorf("can't insert row %d into partition %d: %w", row, partition, err)
+			}
+
+			c.add(row, value)
+		}
+
+		// Every partition is written by a single goroutine.
+		checksums[partition] = c.sum()
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Manifest{
+		Options:   options,
+		Checksums: checksums,
+		Duration:  time.Since(startTime),
+	}, nil
+}
+
+// Report lists partitions that have no rows or whose checksum doesn't match the one in the manifest.
+type Report struct {
+	Checked  int64
+	Missing  []int64
+	Corrupt  []int64
+	Duration time.Duration
+}
+
+func (r *Report) OK() bool {
+	return len(r.Missing) == 0 && len(r.Corrupt) == 0
+}
+
+// Verify reads the given partitions and compares their checksums with the ones in the manifest.
+func Verify(ctx context.Context, session *gocql.Session, manifest *Manifest, partitions []int64) (*Report, error) {
+	for _, p := range partitions {
+		if p < 0 || p >= int64(len(manifest.Checksums)) {
+			return nil, fmt.Errorf("partition %d isn't in the manifest of %d partitions", p, len(manifest.Checksums))
+		}
+	}
+
+	startTime := time.Now()
+	options := manifest.Options
+
+	var lock sync.Mutex
+	report := &Report{}
+	err := forEachPartition(ctx, partitions, options.Concurrency, func(ctx context.Context, partition int64) error {
+		iter := session.Query(
+			fmt.Sprintf(`SELECT row, value FROM %q.%q WHERE partition = ?`, options.Keyspace, tableName),
+			partition,
+		).WithContext(ctx).Consistency(options.Consistency).Iter()
+
+		var c checksummer
+		var rows int
+		var row int
+		var value []byte
+		for iter.Scan(&row, &value) {
+			c.add(row, value)
+			rows++
+		}
+		err := iter.Close()
+		if err != nil {
+			return fmt.Errorf("can't read partition %d: %w", partition, err)
+		}
+
+		lock.Lock()
+		defer lock.Unlock()
+
+		report.Checked++
+		switch {
+		case rows == 0:
+			report.Missing = append(report.Missing, partition)
+		case c.sum() != manifest.Checksums[partition]:
+			report.Corrupt = append(report.Corrupt, partition)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.Sort(report.Missing)
+	slices.Sort(report.Corrupt)
+	report.Duration = time.Since(startTime)
+
+	return report, nil
+}
@@ -0,0 +1,111 @@
+package integrity
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gocql/gocql"
+)
+
+func TestOptionsValidate(t *testing.T) {
+	t.Parallel()
+
+	valid := Options{
+		Keyspace:          DefaultKeyspace,
+		Partitions:        100,
+		RowsPerPartition:  DefaultRowsPerPartition,
+		RowSizeBytes:      DefaultRowSizeBytes,
+		Concurrency:       DefaultConcurrency,
+		ReplicationFactor: 3,
+		Consistency:       gocql.All,
+	}
+	err := valid.Validate()
+	if err != nil {
+		t.Fatalf("expected valid options, got %v", err)
+	}
+
+	invalid := Options{
+		Consistency: gocql.Any,
+	}
+	err = invalid.Validate()
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+
+	for _, field := range []string{"keyspace", "partitions", "rows per partition", "row size", "concurrency", "replication factor", "consistency"} {
+		if !strings.Contains(err.Error(), field) {
+			t.Errorf("expected error to mention %q, got %v", field, err)
+		}
+	}
+}
+
+func TestValue(t *testing.T) {
+	t.Parallel()
+
+	v := Value(42, 7, 3, 100)
+	if len(v) != 100 {
+		t.Fatalf("expected 100 bytes, got %d", len(v))
+	}
+
+	if !bytes.Equal(v, Value(42, 7, 3, 100)) {
+		t.Error("expected the same value for the same seed, partition and row")
+	}
+
+	for _, other := range [][]byte{Value(43, 7, 3, 100), Value(42, 8, 3, 100), Value(42, 7, 4, 100)} {
+		if bytes.Equal(v, other) {
+			t.Error("expected different values for different seeds, partitions or rows")
+		}
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	t.Parallel()
+
+	checksum := func(rows ...[]byte) Checksum {
+		var c checksummer
+		for i, value := range rows {
+			c.add(i, value)
+		}
+		return c.sum()
+	}
+
+	a, b := Value(1, 0, 0, 16), Value(1, 0, 1, 16)
+	expected := checksum(a, b)
+
+	tt := []struct {
+		name string
+		got  Checksum
+	}{
+		{
+			name: "missing row",
+			got:  checksum(a),
+		},
+		{
+			name: "swapped rows",
+			got:  checksum(b, a),
+		},
+		{
+			name: "truncated value",
+			got:  checksum(a, b[:15]),
+		},
+		{
+			name: "flipped bit",
+			got:  checksum(a, append(append([]byte{}, b[:15]...), b[15]^1)),
+		},
+	}
+
+	if got := checksum(a, b); got != expected {
+		t.Errorf("expected checksum %v, got %v", expected, got)
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if tc.got == expected {
+				t.Errorf("expected checksum to differ from %v", expected)
+			}
+		})
+	}
+}
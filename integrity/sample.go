@@ -0,0 +1,63 @@
+package integrity
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+)
+
+// SampleSize returns the number of partitions to verify out of population, so that if at least corruptFraction of
+// them are missing or corrupt, at least one of them is found with the given confidence.
+func SampleSize(population int64, confidence float64, corruptFraction float64) (int64, error) {
+	if confidence <= 0 || confidence >= 1 {
+		return 0, fmt.Errorf("confidence must be between 0 and 1 (exclusive), got %v", confidence)
+	}
+
+	if corruptFraction <= 0 || corruptFraction > 1 {
+		return 0, fmt.Errorf("corrupt fraction must be greater than 0 and at most 1, got %v", corruptFraction)
+	}
+
+	if corruptFraction == 1 {
+		return min(population, 1), nil
+	}
+
+	size := int64(math.Ceil(math.Log(1-confidence) / math.Log(1-corruptFraction)))
+	return min(size, population), nil
+}
+
+// All returns all partitions of the manifest.
+func (m *Manifest) All() []int64 {
+	partitions := make([]int64, 0, len(m.Checksums))
+	for p := range int64(len(m.Checksums)) {
+		partitions = append(partitions, p)
+	}
+
+	return partitions
+}
+
+// Sample returns size distinct partitions of the manifest chosen uniformly at random, in ascending order.
+func (m *Manifest) Sample(size int64, r *rand.Rand) []int64 {
+	population := int64(len(m.Checksums))
+	if size >= population {
+		return m.All()
+	}
+
+	// Robert Floyd's algorithm picks distinct values without materializing the population.
+	chosen := make(map[int64]struct{}, size)
+	for j := population - size; j < population; j++ {
+		p := r.Int64N(j + 1)
+		if _, ok := chosen[p]; ok {
+			p = j
+		}
+		chosen[p] = struct{}{}
+	}
+
+	partitions := make([]int64, 0, size)
+	for p := range chosen {
+		partitions = append(partitions, p)
+	}
+	slices.Sort(partitions)
+
+	return partitions
+}
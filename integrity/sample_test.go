@@ -0,0 +1,118 @@
+package integrity
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestSampleSize(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name            string
+		population      int64
+		confidence      float64
+		corruptFraction float64
+		expected        int64
+		expectedErr     bool
+	}{
+		{
+			name:            "99% confidence of finding 1% corrupt partitions",
+			population:      1_000_000,
+			confidence:      0.99,
+			corruptFraction: 0.01,
+			expected:        459,
+		},
+		{
+			name:            "95% confidence of finding 0.1% corrupt partitions",
+			population:      1_000_000,
+			confidence:      0.95,
+			corruptFraction: 0.001,
+			expected:        2995,
+		},
+		{
+			name:            "capped at population",
+			population:      100,
+			confidence:      0.99,
+			corruptFraction: 0.01,
+			expected:        100,
+		},
+		{
+			name:            "all partitions corrupt",
+			population:      100,
+			confidence:      0.99,
+			corruptFraction: 1,
+			expected:        1,
+		},
+		{
+			name:            "invalid confidence",
+			population:      100,
+			confidence:      1,
+			corruptFraction: 0.01,
+			expectedErr:     true,
+		},
+		{
+			name:            "invalid corrupt fraction",
+			population:      100,
+			confidence:      0.99,
+			corruptFraction: 0,
+			expectedErr:     true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := SampleSize(tc.population, tc.confidence, tc.corruptFraction)
+			if tc.expectedErr {
+				if err == nil {
+					t.Fatal("expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != tc.expected {
+				t.Errorf("expected sample size %d, got %d", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestManifestSample(t *testing.T) {
+	t.Parallel()
+
+	m := &Manifest{
+		Checksums: make([]Checksum, 1000),
+	}
+
+	r := rand.New(rand.NewPCG(1, 2))
+	for _, size := range []int64{0, 1, 10, 999} {
+		got := m.Sample(size, r)
+		if int64(len(got)) != size {
+			t.Fatalf("expected %d partitions, got %d", size, len(got))
+		}
+
+		if !slices.IsSorted(got) {
+			t.Errorf("expected partitions in ascending order, got %v", got)
+		}
+
+		if len(slices.Compact(slices.Clone(got))) != len(got) {
+			t.Errorf("expected distinct partitions, got %v", got)
+		}
+
+		for _, p := range got {
+			if p < 0 || p >= 1000 {
+				t.Errorf("partition %d is out of range", p)
+			}
+		}
+	}
+
+	if got := m.Sample(2000, r); !slices.Equal(got, m.All()) {
+		t.Errorf("expected all partitions when the sample is larger than the population, got %d partitions", len(got))
+	}
+}
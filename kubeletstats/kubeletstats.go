@@ -0,0 +1,146 @@
+package kubeletstats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pausing-clusters-thesis/benchmarks/results"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+// Summary is the subset of the kubelet summary API response used to measure resource usage of pods.
+type Summary struct {
+	Pods []PodStats `json:"pods"`
+}
+
+type PodReference struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	UID       string `json:"uid"`
+}
+
+type PodStats struct {
+	PodRef PodReference `json:"podRef"`
+	// CPU and Memory are the usage of all containers of the pod. They are missing until the kubelet collects them.
+	CPU    *CPUStats    `json:"cpu,omitempty"`
+	Memory *MemoryStats `json:"memory,omitempty"`
+}
+
+type CPUStats struct {
+	Time                 metav1.Time `json:"time"`
+	UsageNanoCores       *uint64     `json:"usageNanoCores,omitempty"`
+	UsageCoreNanoSeconds *uint64     `json:"usageCoreNanoSeconds,omitempty"`
+}
+
+type MemoryStats struct {
+	Time            metav1.Time `json:"time"`
+	WorkingSetBytes *uint64     `json:"workingSetBytes,omitempty"`
+}
+
+func ParseSummary(data []byte) (*Summary, error) {
+	summary := &Summary{}
+	err := json.Unmarshal(data, summary)
+	if err != nil {
+		return nil, fmt.Errorf("can't decode summary: %w", err)
+	}
+
+	return summary, nil
+}
+
+// GetSummary gets the summary of the node from its kubelet, through the API server proxy.
+func GetSummary(ctx context.Context, client rest.Interface, nodeName string) (*Summary, error) {
+	data, err := client.Get().Resource("nodes").Name(nodeName).SubResource("proxy").Suffix("stats", "summary").DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("can't get summary of node %q: %w", nodeName, err)
+	}
+
+	return ParseSummary(data)
+}
+
+func (s *Summary) Pod(namespace, name string) (*PodStats, bool) {
+	for i := range s.Pods {
+		if s.Pods[i].PodRef.Namespace == namespace && s.Pods[i].PodRef.Name == name {
+			return &s.Pods[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// Sample is the usage of a pod at the time the kubelet collected its stats.
+type Sample struct {
+	Time                 time.Time
+	UsageCoreNanoSeconds uint64
+	WorkingSetBytes      uint64
+}
+
+func (ps *PodStats) Sample() (Sample, bool) {
+	if ps.CPU == nil || ps.CPU.UsageCoreNanoSeconds == nil || ps.Memory == nil || ps.Memory.WorkingSetBytes == nil {
+		return Sample{}, false
+	}
+
+	return Sample{
+		Time:                 ps.CPU.Time.Time,
+		UsageCoreNanoSeconds: *ps.CPU.UsageCoreNanoSeconds,
+		WorkingSetBytes:      *ps.Memory.WorkingSetBytes,
+	}, true
+}
+
+// SamplePod samples the usage of the pod every interval for the duration of the window.
+// The kubelet only collects stats periodically, so samples collected at the same time as the previous one are dropped.
+func SamplePod(ctx context.Context, client rest.Interface, nodeName, namespace, name string, window, interval time.Duration) ([]Sample, error) {
+	deadline := time.Now().Add(window)
+
+	var samples []Sample
+	for {
+		summary, err := GetSummary(ctx, client, nodeName)
+		if err != nil {
+			return nil, err
+		}
+
+		ps, ok := summary.Pod(namespace, name)
+		if ok {
+			sample, ok := ps.Sample()
+			if ok && (len(samples) == 0 || sample.Time.After(samples[len(samples)-1].Time)) {
+				samples = append(samples, sample)
+			}
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			return samples, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Usage aggregates samples of a pod. CPU usage is averaged over the time between the first and the last sample.
+func Usage(samples []Sample) (*results.ResourceUsage, error) {
+	if len(samples) < 2 {
+		return nil, fmt.Errorf("at least 2 samples collected at different times are required, got %d", len(samples))
+	}
+
+	first, last := samples[0], samples[len(samples)-1]
+	window := last.Time.Sub(first.Time)
+
+	var sumWorkingSetBytes, maxWorkingSetBytes uint64
+	for _, s := range samples {
+		sumWorkingSetBytes += s.WorkingSetBytes
+		maxWorkingSetBytes = max(maxWorkingSetBytes, s.WorkingSetBytes)
+	}
+
+	return &results.ResourceUsage{
+		Samples:                  len(samples),
+		WindowMs:                 window.Milliseconds(),
+		CPUMillicores:            float64(last.UsageCoreNanoSeconds-first.UsageCoreNanoSeconds) / float64(window.Nanoseconds()) * 1000,
+		MemoryWorkingSetBytes:    int64(sumWorkingSetBytes / uint64(len(samples))),
+		MaxMemoryWorkingSetBytes: int64(maxWorkingSetBytes),
+	}, nil
+}
@@ -0,0 +1,182 @@
+package kubeletstats
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pausing-clusters-thesis/benchmarks/results"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+func TestParseSummary(t *testing.T) {
+	t.Parallel()
+
+	data, err := os.ReadFile(filepath.Join("testdata", "summary.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	summary, err := ParseSummary(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ps, ok := summary.Pod("benchmark-1", "test")
+	if !ok {
+		t.Fatal("expected stats of pod benchmark-1/test")
+	}
+
+	sample, ok := ps.Sample()
+	if !ok {
+		t.Fatal("expected a sample of pod benchmark-1/test")
+	}
+
+	expected := Sample{
+		Time:                 time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC),
+		UsageCoreNanoSeconds: 59000000000,
+		WorkingSetBytes:      1048576,
+	}
+	if !sample.Time.Equal(expected.Time) || sample.UsageCoreNanoSeconds != expected.UsageCoreNanoSeconds || sample.WorkingSetBytes != expected.WorkingSetBytes {
+		t.Errorf("expected sample %+v, got %+v", expected, sample)
+	}
+
+	ps, ok = summary.Pod("benchmark-2", "test")
+	if !ok {
+		t.Fatal("expected stats of pod benchmark-2/test")
+	}
+
+	_, ok = ps.Sample()
+	if ok {
+		t.Error("expected no sample of a pod without collected stats")
+	}
+
+	_, ok = summary.Pod("benchmark-3", "test")
+	if ok {
+		t.Error("expected no stats of an unknown pod")
+	}
+}
+
+func TestUsage(t *testing.T) {
+	t.Parallel()
+
+	startTime := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		name        string
+		samples     []Sample
+		expected    *results.ResourceUsage
+		expectedErr bool
+	}{
+		{
+			name: "busy pod",
+			samples: []Sample{
+				{Time: startTime, UsageCoreNanoSeconds: 10e9, WorkingSetBytes: 1 << 20},
+				{Time: startTime.Add(10 * time.Second), UsageCoreNanoSeconds: 20e9, WorkingSetBytes: 3 << 20},
+				{Time: startTime.Add(20 * time.Second), UsageCoreNanoSeconds: 30e9, WorkingSetBytes: 2 << 20},
+			},
+			expected: &results.ResourceUsage{
+				Samples:                  3,
+				WindowMs:                 20000,
+				CPUMillicores:            1000,
+				MemoryWorkingSetBytes:    2 << 20,
+				MaxMemoryWorkingSetBytes: 3 << 20,
+			},
+		},
+		{
+			name: "idle pod",
+			samples: []Sample{
+				{Time: startTime, UsageCoreNanoSeconds: 5e6, WorkingSetBytes: 1 << 20},
+				{Time: startTime.Add(20 * time.Second), UsageCoreNanoSeconds: 25e6, WorkingSetBytes: 1 << 20},
+			},
+			expected: &results.ResourceUsage{
+				Samples:                  2,
+				WindowMs:                 20000,
+				CPUMillicores:            1,
+				MemoryWorkingSetBytes:    1 << 20,
+				MaxMemoryWorkingSetBytes: 1 << 20,
+			},
+		},
+		{
+			name: "single sample",
+			samples: []Sample{
+				{Time: startTime, UsageCoreNanoSeconds: 10e9, WorkingSetBytes: 1 << 20},
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Usage(tc.samples)
+			if tc.expectedErr {
+				if err == nil {
+					t.Errorf("expected an error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected usage %+v, got %+v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestSamplePod(t *testing.T) {
+	t.Parallel()
+
+	startTime := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	var requests atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/nodes/node-1/proxy/stats/summary" {
+			http.NotFound(w, r)
+			return
+		}
+
+		// The kubelet collects stats less often than they are requested, so every stat is served twice.
+		n := (requests.Add(1) - 1) / 2
+		statsTime := startTime.Add(time.Duration(n) * 10 * time.Second).Format(time.RFC3339)
+		fmt.Fprintf(w, `{"pods": [{"podRef": {"name": "test", "namespace": "benchmark"}, "cpu": {"time": %q, "usageCoreNanoSeconds": %d}, "memory": {"time": %q, "workingSetBytes": 1048576}}]}`, statsTime, n*1e9, statsTime)
+	}))
+	defer srv.Close()
+
+	client, err := kubernetes.NewForConfig(&rest.Config{Host: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	samples, err := SamplePod(context.Background(), client.CoreV1().RESTClient(), "node-1", "benchmark", "test", 50*time.Millisecond, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(samples) < 2 {
+		t.Fatalf("expected at least 2 samples, got %d", len(samples))
+	}
+
+	for i, s := range samples {
+		if expected := startTime.Add(time.Duration(i) * 10 * time.Second); !s.Time.Equal(expected) {
+			t.Errorf("expected sample %d to be collected at %v, got %v", i, expected, s.Time)
+		}
+	}
+
+	_, err = SamplePod(context.Background(), client.CoreV1().RESTClient(), "node-2", "benchmark", "test", 0, time.Millisecond)
+	if err == nil {
+		t.Error("expected an error for an unknown node")
+	}
+}
@@ -0,0 +1,221 @@
+package load
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/pausing-clusters-thesis/benchmarks/workload"
+	"github.com/scylladb/gocqlx/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	DefaultKeyspace = "load"
+	DefaultWindow   = time.Second
+
+	tableName = "kv"
+)
+
+type Options struct {
+	Keyspace    string
+	Consistency gocql.Consistency
+	// Rate is the number of operations per second issued regardless of how fast they complete.
+	Rate int
+	// ReadRatio is the fraction of operations that are reads, the rest are writes.
+	ReadRatio      float64
+	Keys           int64
+	ValueSizeBytes int
+	Concurrency    int
+	// Window is the interval over which latency and throughput are aggregated.
+	Window time.Duration
+	Seed   uint64
+}
+
+func (o *Options) Validate() error {
+	var errs []error
+
+	if len(o.Keyspace) == 0 {
+		errs = append(errs, errors.New("keyspace must not be empty"))
+	}
+
+	if o.Consistency == gocql.Any || o.Consistency.IsSerial() {
+		errs = append(errs, fmt.Errorf("consistency %v can't be used for both reads and writes", o.Consistency))
+	}
+
+	if o.Rate <= 0 {
+		errs = append(errs, fmt.Errorf("rate must be greater than zero, got %d", o.Rate))
+	}
+
+	if o.ReadRatio < 0 || o.ReadRatio > 1 {
+		errs = append(errs, fmt.Errorf("read ratio must be between 0 and 1, got %v", o.ReadRatio))
+	}
+
+	if o.Keys <= 0 {
+		errs = append(errs, fmt.Errorf("keys must be greater than zero, got %d", o.Keys))
+	}
+
+	if o.ValueSizeBytes < 0 {
+		errs = append(errs, fmt.Errorf("value size must not be negative, got %d", o.ValueSizeBytes))
+	}
+
+	if o.Concurrency <= 0 {
+		errs = append(errs, fmt.Errorf("concurrency must be greater than zero, got %d", o.Concurrency))
+	}
+
+	if o.Window <= 0 {
+		errs = append(errs, fmt.Errorf("window must be greater than zero, got %v", o.Window))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (o *Options) spec(duration time.Duration) workload.Spec {
+	return workload.Spec{
+		Schema: workload.Schema{
+			Keyspace: o.Keyspace,
+			Table:    tableName,
+			// The replication factor is only used by Prepare to create the schema.
+			ReplicationFactor: 1,
+		},
+		Keys: workload.KeyDistribution{
+			Type:  workload.UniformDistributionType,
+			Count: o.Keys,
+		},
+		RowSizeBytes: o.ValueSizeBytes,
+		ReadRatio:    o.ReadRatio,
+		Consistency:  o.Consistency.String(),
+		Rate:         o.Rate,
+		Duration:     metav1.Duration{Duration: duration},
+		Concurrency:  o.Concurrency,
+	}
+}
+
+// Prepare creates the table the load is run against. It has to be called while the cluster is available.
+func Prepare(ctx context.Context, session gocqlx.Session, keyspace string, replicationFactor int) error {
+	return workload.Prepare(ctx, session, workload.Schema{
+		Keyspace:          keyspace,
+		Table:             tableName,
+		ReplicationFactor: replicationFactor,
+	})
+}
+
+// Generator runs a uniform workload and aggregates its outcomes into windows.
+type Generator struct {
+	session gocqlx.Session
+	options Options
+}
+
+func NewGenerator(session gocqlx.Session, options Options) (*Generator, error) {
+	err := options.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid load options: %w", err)
+	}
+
+	return &Generator{
+		session: session,
+		options: options,
+	}, nil
+}
+
+// Run generates load for the duration, or until stop returns true. Stop is called with the windows completed so far
+// every time a window completes. Only complete windows are returned.
+func (g *Generator) Run(ctx context.Context, duration time.Duration, stop func([]Window) bool) ([]Window, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	c := newCollector(time.Now(), g.options.Window)
+
+	if stop != nil {
+		go func() {
+			ticker := time.NewTicker(g.options.Window)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if stop(c.completeWindows(time.Now())) {
+						cancel()
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	_, err := workload.Run(ctx, g.session, g.options.spec(duration), workload.Options{
+		Seed: g.options.Seed,
+		OnOperation: func(op workload.Operation) {
+			c.add(op.ScheduledTime, op.CompletionTime, op.Err)
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't run workload: %w", err)
+	}
+
+	return c.completeWindows(time.Now()), nil
+}
+
+type collector struct {
+	lock      sync.Mutex
+	startTime time.Time
+	window    time.Duration
+	windows   []Window
+}
+
+func newCollector(startTime time.Time, window time.Duration) *collector {
+	return &collector{
+		startTime: startTime,
+		window:    window,
+	}
+}
+
+func (c *collector) add(scheduledTime, completionTime time.Time, err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	idx := int(completionTime.Sub(c.startTime) / c.window)
+	for len(c.windows) <= idx {
+		c.windows = append(c.windows, Window{
+			StartTime: c.startTime.Add(time.Duration(len(c.windows)) * c.window),
+			Duration:  c.window,
+		})
+	}
+
+	w := &c.windows[idx]
+	if err != nil {
+		w.Errors++
+		return
+	}
+
+	w.LatenciesMs = append(w.LatenciesMs, float64(completionTime.Sub(scheduledTime).Microseconds())/1000)
+}
+
+// completeWindows returns copies of the windows that ended before now, including the empty ones.
+func (c *collector) completeWindows(now time.Time) []Window {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	n := int(now.Sub(c.startTime) / c.window)
+	windows := make([]Window, 0, n)
+	for i := range n {
+		if i < len(c.windows) {
+			w := c.windows[i]
+			w.LatenciesMs = append([]float64(nil), w.LatenciesMs...)
+			windows = append(windows, w)
+			continue
+		}
+
+		windows = append(windows, Window{
+			StartTime: c.startTime.Add(time.Duration(i) * c.window),
+			Duration:  c.window,
+		})
+	}
+
+	return windows
+}
@@ -0,0 +1,107 @@
+package load
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"github.com/pausing-clusters-thesis/benchmarks/stats"
+)
+
+// Window aggregates the operations that completed within an interval.
+type Window struct {
+	StartTime   time.Time
+	Duration    time.Duration
+	LatenciesMs []float64
+	Errors      int
+}
+
+// Throughput returns the number of successful operations per second.
+func (w *Window) Throughput() float64 {
+	return float64(len(w.LatenciesMs)) / w.Duration.Seconds()
+}
+
+func (w *Window) P99LatencyMs() float64 {
+	if len(w.LatenciesMs) == 0 {
+		return math.NaN()
+	}
+
+	return stats.Percentile(slices.Sorted(slices.Values(w.LatenciesMs)), 99)
+}
+
+// Baseline is the latency and throughput of a cluster under load in its steady state.
+type Baseline struct {
+	P99LatencyMs float64
+	Throughput   float64
+}
+
+func NewBaseline(windows []Window) Baseline {
+	var latenciesMs []float64
+	var duration time.Duration
+	for _, w := range windows {
+		latenciesMs = append(latenciesMs, w.LatenciesMs...)
+		duration += w.Duration
+	}
+
+	if len(latenciesMs) == 0 {
+		return Baseline{
+			P99LatencyMs: math.NaN(),
+		}
+	}
+
+	return Baseline{
+		P99LatencyMs: stats.Percentile(slices.Sorted(slices.Values(latenciesMs)), 99),
+		Throughput:   float64(len(latenciesMs)) / duration.Seconds(),
+	}
+}
+
+// IsLatencySteady reports whether the p99 latency of the window is at most tolerance above the baseline.
+func (b Baseline) IsLatencySteady(tolerance float64) func(Window) bool {
+	return func(w Window) bool {
+		return w.P99LatencyMs() <= b.P99LatencyMs*(1+tolerance)
+	}
+}
+
+// IsThroughputSteady reports whether the throughput of the window is at most tolerance below the baseline.
+func (b Baseline) IsThroughputSteady(tolerance float64) func(Window) bool {
+	return func(w Window) bool {
+		return w.Throughput() >= b.Throughput*(1-tolerance)
+	}
+}
+
+// AllSteady combines conditions that all have to hold for a window to be steady.
+func AllSteady(conditions ...func(Window) bool) func(Window) bool {
+	return func(w Window) bool {
+		for _, c := range conditions {
+			if !c(w) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+// TimeToSteadyState returns the time from the start of the first window until the end of the first window
+// of a run of stableWindows consecutive steady windows.
+func TimeToSteadyState(windows []Window, stableWindows int, isSteady func(Window) bool) (time.Duration, bool) {
+	if len(windows) == 0 {
+		return 0, false
+	}
+
+	run := 0
+	for i, w := range windows {
+		if !isSteady(w) {
+			run = 0
+			continue
+		}
+
+		run++
+		if run == stableWindows {
+			first := windows[i-stableWindows+1]
+			return first.StartTime.Add(first.Duration).Sub(windows[0].StartTime), true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,149 @@
+package load
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+func newWindows(startTime time.Time, latenciesMs ...[]float64) []Window {
+	var windows []Window
+	for i, l := range latenciesMs {
+		windows = append(windows, Window{
+			StartTime:   startTime.Add(time.Duration(i) * time.Second),
+			Duration:    time.Second,
+			LatenciesMs: l,
+		})
+	}
+
+	return windows
+}
+
+func repeat(latencyMs float64, n int) []float64 {
+	latencies := make([]float64, 0, n)
+	for range n {
+		latencies = append(latencies, latencyMs)
+	}
+
+	return latencies
+}
+
+func TestNewBaseline(t *testing.T) {
+	t.Parallel()
+
+	startTime := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	baseline := NewBaseline(newWindows(startTime, repeat(2, 100), repeat(4, 100)))
+
+	if baseline.Throughput != 100 {
+		t.Errorf("expected throughput 100, got %v", baseline.Throughput)
+	}
+	if baseline.P99LatencyMs != 4 {
+		t.Errorf("expected p99 latency 4ms, got %v", baseline.P99LatencyMs)
+	}
+
+	empty := NewBaseline(newWindows(startTime, nil))
+	if !math.IsNaN(empty.P99LatencyMs) || empty.Throughput != 0 {
+		t.Errorf("expected an undefined baseline without operations, got %#v", empty)
+	}
+}
+
+func TestTimeToSteadyState(t *testing.T) {
+	t.Parallel()
+
+	startTime := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	baseline := Baseline{P99LatencyMs: 10, Throughput: 100}
+	windows := newWindows(startTime,
+		nil,
+		repeat(50, 40),
+		repeat(10, 100),
+		repeat(30, 100),
+		repeat(11, 95),
+		repeat(10, 100),
+		repeat(5, 100),
+	)
+
+	tt := []struct {
+		name          string
+		isSteady      func(Window) bool
+		stableWindows int
+		expected      time.Duration
+		expectedOK    bool
+	}{
+		{
+			name:          "latency",
+			isSteady:      baseline.IsLatencySteady(0.1),
+			stableWindows: 1,
+			expected:      3 * time.Second,
+			expectedOK:    true,
+		},
+		{
+			name:          "stable latency",
+			isSteady:      baseline.IsLatencySteady(0.1),
+			stableWindows: 2,
+			expected:      5 * time.Second,
+			expectedOK:    true,
+		},
+		{
+			name:          "throughput",
+			isSteady:      baseline.IsThroughputSteady(0.1),
+			stableWindows: 1,
+			expected:      3 * time.Second,
+			expectedOK:    true,
+		},
+		{
+			name:          "both with strict throughput",
+			isSteady:      AllSteady(baseline.IsLatencySteady(0.1), baseline.IsThroughputSteady(0.01)),
+			stableWindows: 2,
+			expected:      6 * time.Second,
+			expectedOK:    true,
+		},
+		{
+			name:          "never stable long enough",
+			isSteady:      baseline.IsLatencySteady(0.1),
+			stableWindows: 4,
+			expectedOK:    false,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := TimeToSteadyState(windows, tc.stableWindows, tc.isSteady)
+			if ok != tc.expectedOK {
+				t.Fatalf("expected ok %t, got %t", tc.expectedOK, ok)
+			}
+			if got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestOptionsValidate(t *testing.T) {
+	t.Parallel()
+
+	options := Options{
+		Keyspace:       DefaultKeyspace,
+		Consistency:    gocql.Quorum,
+		Rate:           100,
+		ReadRatio:      0.5,
+		Keys:           1000,
+		ValueSizeBytes: 128,
+		Concurrency:    8,
+		Window:         DefaultWindow,
+	}
+	err := options.Validate()
+	if err != nil {
+		t.Errorf("expected valid options, got %v", err)
+	}
+
+	options.ReadRatio = 1.5
+	options.Rate = 0
+	err = options.Validate()
+	if err == nil {
+		t.Errorf("expected an error for invalid options")
+	}
+}
@@ -0,0 +1,153 @@
+package pausable_scylladb_operator_benchmarks_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/gocql/gocql/scyllacloud"
+	"github.com/pausing-clusters-thesis/benchmarks/cqltrace"
+	"github.com/pausing-clusters-thesis/benchmarks/framework"
+	"github.com/pausing-clusters-thesis/benchmarks/results"
+	pausingv1alpha1 "github.com/pausing-clusters-thesis/pausable-scylladb-operator/pkg/api/pausing/v1alpha1"
+	psonaming "github.com/pausing-clusters-thesis/pausable-scylladb-operator/pkg/naming"
+	scyllav1alpha1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1alpha1"
+	sonaming "github.com/scylladb/scylla-operator/pkg/naming"
+	"github.com/scylladb/scylla-operator/pkg/scheme"
+	cqlclientv1alpha1 "github.com/scylladb/scylla-operator/pkg/scylla/api/cqlclient/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+)
+
+func getBoundScyllaDBDatacenter(ctx context.Context, c *framework.Cluster, namespace string, psdc *pausingv1alpha1.PausableScyllaDBDatacenter) (*scyllav1alpha1.ScyllaDBDatacenter, error) {
+	sdcc, err := c.PausingAdminClient().PausingV1alpha1().ScyllaDBDatacenterClaims(namespace).Get(ctx, psonaming.GetScyllaDBDatacenterClaimNameForPausableScyllaDBDatacenter(psdc), metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("can't get ScyllaDBDatacenterClaim of PausableScyllaDBDatacenter %q: %w", psdc.Name, err)
+	}
+
+	if sdcc.Status.ScyllaDBDatacenterName == nil || len(*sdcc.Status.ScyllaDBDatacenterName) == 0 {
+		return nil, fmt.Errorf("ScyllaDBDatacenterClaim %q isn't bound", sdcc.Name)
+	}
+
+	sdc, err := c.ScyllaAdminClient().ScyllaV1alpha1().ScyllaDBDatacenters(namespace).Get(ctx, *sdcc.Status.ScyllaDBDatacenterName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("can't get ScyllaDBDatacenter %q: %w", *sdcc.Status.ScyllaDBDatacenterName, err)
+	}
+
+	return sdc, nil
+}
+
+// writeCQLConnectionConfig saves the connection config of the ScyllaDBDatacenter, pointed at the ingress controller, in dir.
+func writeCQLConnectionConfig(ctx context.Context, c *framework.Cluster, sdc *scyllav1alpha1.ScyllaDBDatacenter, dir string) (string, error) {
+	if len(sdc.Spec.DNSDomains) != 1 {
+		return "", fmt.Errorf("expected ScyllaDBDatacenter %q to have exactly one DNS domain, got %d", sdc.Name, len(sdc.Spec.DNSDomains))
+	}
+	dnsDomain := sdc.Spec.DNSDomains[0]
+
+	var groupVersioner runtime.GroupVersioner = schema.GroupVersions([]schema.GroupVersion{cqlclientv1alpha1.GroupVersion})
+	decoder := scheme.Codecs.DecoderToVersion(scheme.Codecs.UniversalDeserializer(), groupVersioner)
+	encoder := scheme.Codecs.EncoderForVersion(scheme.DefaultYamlSerializer, groupVersioner)
+
+	bundleSecret, err := c.KubeAdminClient().CoreV1().Secrets(sdc.Namespace).Get(ctx, sonaming.GetScyllaClusterLocalAdminCQLConnectionConfigsName(sdc.Name), metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("can't get CQL connection configs of ScyllaDBDatacenter %q: %w", sdc.Name, err)
+	}
+
+	cqlConnectionConfig := &cqlclientv1alpha1.CQLConnectionConfig{}
+	_, _, err = decoder.Decode(bundleSecret.Data[dnsDomain], nil, cqlConnectionConfig)
+	if err != nil {
+		return "", fmt.Errorf("can't decode CQL connection config for domain %q: %w", dnsDomain, err)
+	}
+
+	gossipDatacenterName := sonaming.GetScyllaDBDatacenterGossipDatacenterName(sdc)
+	datacenter, ok := cqlConnectionConfig.Datacenters[gossipDatacenterName]
+	if !ok || datacenter == nil {
+		return "", fmt.Errorf("CQL connection config for domain %q has no datacenter %q", dnsDomain, gossipDatacenterName)
+	}
+	datacenter.Server = ingressControllerAddress
+
+	cqlConnectionConfigData, err := runtime.Encode(encoder, cqlConnectionConfig)
+	if err != nil {
+		return "", fmt.Errorf("can't encode CQL connection config: %w", err)
+	}
+
+	cqlConnectionConfigFilePath := path.Join(dir, fmt.Sprintf("%s.yaml", dnsDomain))
+	err = os.WriteFile(cqlConnectionConfigFilePath, cqlConnectionConfigData, 0600)
+	if err != nil {
+		return "", fmt.Errorf("can't write CQL connection config to %q: %w", cqlConnectionConfigFilePath, err)
+	}
+
+	return cqlConnectionConfigFilePath, nil
+}
+
+func newCloudCluster(cqlConnectionConfigFilePath string) (*gocql.ClusterConfig, error) {
+	cluster, err := scyllacloud.NewCloudCluster(cqlConnectionConfigFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("can't create cluster config from %q: %w", cqlConnectionConfigFilePath, err)
+	}
+
+	// Increase default timeout, due to additional hop on the route to host.
+	cluster.Timeout = 10 * time.Second
+	cluster.Logger = nopLogger{}
+	cluster.Consistency = gocql.Quorum
+
+	return cluster, nil
+}
+
+// newTracedCluster returns a copy of the cluster whose sessions report timings of connections to each host to the tracer.
+func newTracedCluster(cluster *gocql.ClusterConfig, cqlConnectionConfigFilePath string) (*gocql.ClusterConfig, *cqltrace.Tracer, error) {
+	cqlConnectionConfigData, err := os.ReadFile(cqlConnectionConfigFilePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("can't read CQL connection config %q: %w", cqlConnectionConfigFilePath, err)
+	}
+
+	connectionConfig := &scyllacloud.ConnectionConfig{}
+	err = yaml.Unmarshal(cqlConnectionConfigData, connectionConfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("can't decode CQL connection config %q: %w", cqlConnectionConfigFilePath, err)
+	}
+
+	tracer := cqltrace.NewTracer()
+	tracedCluster := *cluster
+	tracer.Instrument(&tracedCluster, scyllacloud.NewSniHostDialer(connectionConfig, tracer.Dialer(&net.Dialer{})))
+
+	return &tracedCluster, tracer, nil
+}
+
+func getHostConnections(startTime time.Time, hosts []cqltrace.HostTimings) []results.HostConnection {
+	var connections []results.HostConnection
+	for _, ht := range hosts {
+		if ht.FirstConnect == nil {
+			continue
+		}
+
+		hc := results.HostConnection{
+			HostID:          ht.HostID,
+			Address:         ht.Address,
+			ConnectAttempts: ht.ConnectAttempts,
+			FailedConnects:  ht.FailedConnects,
+			ConnectOffsetMs: ht.FirstConnect.End.Sub(startTime).Milliseconds(),
+			ConnectMs:       ht.FirstConnect.Duration().Milliseconds(),
+		}
+
+		if ht.FirstDial != nil {
+			hc.TCPMs = ht.FirstDial.TCP.Duration().Milliseconds()
+			hc.TLSMs = ht.FirstDial.TLSDuration().Milliseconds()
+		}
+
+		if ht.FirstQuery != nil {
+			hc.FirstQueryOffsetMs = ht.FirstQuery.End.Sub(startTime).Milliseconds()
+			hc.FirstQueryMs = ht.FirstQuery.Duration().Milliseconds()
+		}
+
+		connections = append(connections, hc)
+	}
+
+	return connections
+}
@@ -0,0 +1,224 @@
+package pausable_scylladb_operator_benchmarks_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gocql/gocql"
+	g "github.com/onsi/ginkgo/v2"
+	o "github.com/onsi/gomega"
+	"github.com/pausing-clusters-thesis/benchmarks/framework"
+	"github.com/pausing-clusters-thesis/benchmarks/results"
+	"github.com/pausing-clusters-thesis/benchmarks/utils"
+	pausingv1alpha1 "github.com/pausing-clusters-thesis/pausable-scylladb-operator/pkg/api/pausing/v1alpha1"
+	psocontrollerhelpers "github.com/pausing-clusters-thesis/pausable-scylladb-operator/pkg/controllerhelpers"
+	psonaming "github.com/pausing-clusters-thesis/pausable-scylladb-operator/pkg/naming"
+	scyllav1alpha1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1alpha1"
+	socontrollerhelpers "github.com/scylladb/scylla-operator/pkg/controllerhelpers"
+	soframework "github.com/scylladb/scylla-operator/test/e2e/framework"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	warmInstanceLabelValue = "warm"
+	coldInstanceLabelValue = "cold"
+
+	psdcLabel     = "psdc"
+	instanceLabel = "instance"
+)
+
+type herdMember struct {
+	psdc    *pausingv1alpha1.PausableScyllaDBDatacenter
+	cluster *gocql.ClusterConfig
+
+	startTime time.Time
+	stopTime  time.Time
+	err       error
+}
+
+// unpauseTogether connects to all paused members at once, which unpauses them, and records when each connection succeeded.
+func unpauseTogether(members []*herdMember) error {
+	var wg sync.WaitGroup
+	startCh := make(chan struct{})
+	for _, m := range members {
+		wg.Add(1)
+		go func() {
+			defer g.GinkgoRecover()
+			defer wg.Done()
+
+			<-startCh
+			m.startTime = time.Now()
+			session, err := m.cluster.CreateSession()
+			m.stopTime = time.Now()
+			if err != nil {
+				m.err = fmt.Errorf("can't connect to PausableScyllaDBDatacenter %q: %w", m.psdc.Name, err)
+				return
+			}
+			session.Close()
+		}()
+	}
+
+	close(startCh)
+	wg.Wait()
+
+	var errs []error
+	for _, m := range members {
+		errs = append(errs, m.err)
+	}
+
+	return errors.Join(errs...)
+}
+
+var _ = g.Describe("measure concurrent unpause", func() {
+	f := framework.NewFramework("benchmark")
+
+	g.It("when unpausing more PausableScyllaDBDatacenters than pool capacity at once", func(ctx g.SpecContext) {
+		resultsName := fmt.Sprintf("herd-%d-capacity-%d", herdSize, herdPoolCapacity)
+		resultSinks := map[string]results.ResultSink{}
+		for _, instance := range []string{warmInstanceLabelValue, coldInstanceLabelValue} {
+			resultSink, err := results.NewSink(resultSinkOptions, fmt.Sprintf("%s-%s", resultsName, instance))
+			o.Expect(err).NotTo(o.HaveOccurred())
+			g.DeferCleanup(resultSink.Close)
+			resultSinks[instance] = resultSink
+		}
+
+		c := f.Cluster(0)
+		ns, nsClient, ok := c.DefaultNamespaceIfAny()
+		o.Expect(ok).To(o.BeTrue())
+
+		backendImmediateStorageClass, err := utils.GetImmediateStorageClassForCSIDriver(backendCSIDriverName)
+		o.Expect(err).NotTo(o.HaveOccurred())
+
+		framework.By("Creating immediate StorageClass for backend CSI driver")
+		backendImmediateStorageClass, err = c.KubeAdminClient().StorageV1().StorageClasses().Create(ctx, backendImmediateStorageClass, metav1.CreateOptions{})
+		o.Expect(err).NotTo(o.HaveOccurred())
+
+		g.DeferCleanup(func(ctx g.SpecContext, backendImmediateStorageClass *storagev1.StorageClass) {
+			framework.By("Deleting immediate StorageClass")
+			err := c.KubeAdminClient().StorageV1().StorageClasses().Delete(ctx, backendImmediateStorageClass.GetName(), metav1.DeleteOptions{})
+			o.Expect(err).NotTo(o.HaveOccurred())
+		}, backendImmediateStorageClass)
+
+		framework.By("Creating a ScyllaDBDatacenterPool")
+		sdcp := getScyllaDBDatacenterPool("basic", backendImmediateStorageClass.GetName(), int32(herdPoolCapacity), int32(herdPoolCapacity))
+		sdcp, err = c.PausingAdminClient().PausingV1alpha1().ScyllaDBDatacenterPools(ns.GetName()).Create(ctx, sdcp, metav1.CreateOptions{})
+		o.Expect(err).NotTo(o.HaveOccurred())
+
+		framework.By("Creating %d PausableScyllaDBDatacenters in an unpaused state", herdSize)
+		members := make([]*herdMember, 0, herdSize)
+		for i := range herdSize {
+			psdc := getPausableScyllaDBDatacenter(fmt.Sprintf("tenant-%d", i), sdcp.GetName())
+			psdc, err = c.PausingAdminClient().PausingV1alpha1().PausableScyllaDBDatacenters(ns.GetName()).Create(ctx, psdc, metav1.CreateOptions{})
+			o.Expect(err).NotTo(o.HaveOccurred())
+			members = append(members, &herdMember{psdc: psdc})
+		}
+
+		connectionBundleDir, err := os.MkdirTemp(os.TempDir(), fmt.Sprintf("connection-bundle-%s-", ns.GetName()))
+		o.Expect(err).NotTo(o.HaveOccurred())
+		defer func() {
+			err := os.RemoveAll(connectionBundleDir)
+			o.Expect(err).NotTo(o.HaveOccurred())
+		}()
+
+		sdcs := make([]*scyllav1alpha1.ScyllaDBDatacenter, 0, len(members))
+		for _, m := range members {
+			framework.By("Waiting for PausableScyllaDBDatacenter %q to roll out", m.psdc.Name)
+			m.psdc, err = psocontrollerhelpers.WaitForPausableScyllaDBDatacenterState(ctx, c.PausingAdminClient().PausingV1alpha1().PausableScyllaDBDatacenters(ns.GetName()), m.psdc.GetName(), socontrollerhelpers.WaitForStateOptions{}, psocontrollerhelpers.IsPausableScyllaDBDatacenterRolledOut)
+			o.Expect(err).NotTo(o.HaveOccurred())
+
+			sdc, err := getBoundScyllaDBDatacenter(ctx, c, ns.GetName(), m.psdc)
+			o.Expect(err).NotTo(o.HaveOccurred())
+			sdcs = append(sdcs, sdc)
+
+			cqlConnectionConfigFilePath, err := writeCQLConnectionConfig(ctx, c, sdc, connectionBundleDir)
+			o.Expect(err).NotTo(o.HaveOccurred())
+
+			m.cluster, err = newCloudCluster(cqlConnectionConfigFilePath)
+			o.Expect(err).NotTo(o.HaveOccurred())
+		}
+
+		framework.By("Pausing all PausableScyllaDBDatacenters")
+		for _, m := range members {
+			m.psdc, err = c.PausingAdminClient().PausingV1alpha1().PausableScyllaDBDatacenters(ns.GetName()).Patch(
+				ctx,
+				m.psdc.Name,
+				types.JSONPatchType,
+				[]byte(`[{"op": "replace", "path": "/spec/paused", "value": true}]`),
+				metav1.PatchOptions{},
+			)
+			o.Expect(err).NotTo(o.HaveOccurred())
+		}
+
+		for i, m := range members {
+			framework.By("Waiting for PausableScyllaDBDatacenter %q to be paused", m.psdc.Name)
+			m.psdc, err = psocontrollerhelpers.WaitForPausableScyllaDBDatacenterState(ctx, c.PausingAdminClient().PausingV1alpha1().PausableScyllaDBDatacenters(ns.GetName()), m.psdc.GetName(), socontrollerhelpers.WaitForStateOptions{}, psocontrollerhelpers.IsPausableScyllaDBDatacenterRolledOut)
+			o.Expect(err).NotTo(o.HaveOccurred())
+
+			err = soframework.WaitForObjectDeletion(
+				ctx,
+				nsClient.DynamicClient(),
+				scyllav1alpha1.GroupVersion.WithResource("scylladbdatacenters"),
+				ns.GetName(),
+				sdcs[i].GetName(),
+				ptr.To(sdcs[i].GetUID()),
+			)
+			o.Expect(err).NotTo(o.HaveOccurred())
+		}
+
+		framework.By("Waiting for ScyllaDBDatacenterPool to be back at capacity")
+		sdcp, err = psocontrollerhelpers.WaitForScyllaDBDatacenterPoolState(ctx, c.PausingAdminClient().PausingV1alpha1().ScyllaDBDatacenterPools(ns.GetName()), sdcp.GetName(), socontrollerhelpers.WaitForStateOptions{}, psocontrollerhelpers.IsScyllaDBDatacenterPoolRolledOut, isScyllaDBDatacenterPoolAtCapacity)
+		o.Expect(err).NotTo(o.HaveOccurred())
+
+		sdcList, err := c.ScyllaAdminClient().ScyllaV1alpha1().ScyllaDBDatacenters(ns.GetName()).List(ctx, metav1.ListOptions{
+			LabelSelector: psonaming.ScyllaDBDatacenterPoolSelector(sdcp).String(),
+		})
+		o.Expect(err).NotTo(o.HaveOccurred())
+
+		warmInstances := sets.New[string]()
+		for _, sdc := range sdcList.Items {
+			if psocontrollerhelpers.IsScyllaDBDatacenterPrewarmed(&sdc) {
+				warmInstances.Insert(sdc.Name)
+			}
+		}
+		o.Expect(warmInstances.Len()).To(o.Equal(herdPoolCapacity))
+
+		framework.By("Connecting to all paused clusters via Ingress at once")
+		err = unpauseTogether(members)
+		o.Expect(err).NotTo(o.HaveOccurred())
+
+		for _, m := range members {
+			framework.By("Waiting for PausableScyllaDBDatacenter %q to roll out", m.psdc.Name)
+			m.psdc, err = psocontrollerhelpers.WaitForPausableScyllaDBDatacenterState(ctx, c.PausingAdminClient().PausingV1alpha1().PausableScyllaDBDatacenters(ns.GetName()), m.psdc.GetName(), socontrollerhelpers.WaitForStateOptions{}, psocontrollerhelpers.IsPausableScyllaDBDatacenterRolledOut)
+			o.Expect(err).NotTo(o.HaveOccurred())
+
+			sdc, err := getBoundScyllaDBDatacenter(ctx, c, ns.GetName(), m.psdc)
+			o.Expect(err).NotTo(o.HaveOccurred())
+
+			instance := coldInstanceLabelValue
+			if warmInstances.Has(sdc.Name) {
+				instance = warmInstanceLabelValue
+			}
+
+			nodeTimings := getScyllaNodeTimings(ctx, nsClient.KubeClient().CoreV1().Pods(ns.GetName()), sdc)
+			o.Expect(nodeTimings).NotTo(o.BeEmpty())
+
+			res := results.NewRecord(runMetadata, fmt.Sprintf("%s-%s", resultsName, instance), m.startTime, m.stopTime)
+			res.Labels = map[string]string{
+				psdcLabel:     m.psdc.Name,
+				instanceLabel: instance,
+			}
+			setApplicationTimeline(res, nodeTimings)
+			framework.Infof("PausableScyllaDBDatacenter %q got a %s instance %q and was unpaused after %dms.", m.psdc.Name, instance, sdc.Name, res.ElapsedTimeMs)
+
+			err = resultSinks[instance].Write(ctx, res)
+			o.Expect(err).NotTo(o.HaveOccurred())
+		}
+	})
+})
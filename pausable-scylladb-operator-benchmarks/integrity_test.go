@@ -0,0 +1,66 @@
+package pausable_scylladb_operator_benchmarks_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/gocql/gocql"
+	g "github.com/onsi/ginkgo/v2"
+	"github.com/pausing-clusters-thesis/benchmarks/framework"
+	"github.com/pausing-clusters-thesis/benchmarks/integrity"
+	"github.com/pausing-clusters-thesis/benchmarks/results"
+)
+
+func newIntegrityOptions(replicationFactor int) integrity.Options {
+	return integrity.Options{
+		Keyspace:          integrity.DefaultKeyspace,
+		Partitions:        integrityPartitions,
+		RowsPerPartition:  integrity.DefaultRowsPerPartition,
+		RowSizeBytes:      integrity.DefaultRowSizeBytes,
+		Concurrency:       integrity.DefaultConcurrency,
+		ReplicationFactor: replicationFactor,
+		// Writing to and reading from all replicas makes sure every rebound volume holds the data.
+		Consistency: gocql.All,
+		Seed:        uint64(g.GinkgoRandomSeed()),
+	}
+}
+
+func writeIntegrityData(ctx context.Context, session *gocql.Session, replicationFactor int) (*integrity.Manifest, error) {
+	framework.By("Writing %d partitions to verify data integrity after unpausing", integrityPartitions)
+	manifest, err := integrity.Write(ctx, session, newIntegrityOptions(replicationFactor))
+	if err != nil {
+		return nil, fmt.Errorf("can't write integrity data: %w", err)
+	}
+	framework.Infof("Wrote %d partitions in %v.", len(manifest.Checksums), manifest.Duration)
+
+	return manifest, nil
+}
+
+// verifyIntegrity reads all partitions in the manifest, or a random sample of them if a corrupt fraction is set.
+func verifyIntegrity(ctx context.Context, session *gocql.Session, manifest *integrity.Manifest) (*results.Integrity, error) {
+	partitions := manifest.All()
+	if integrityCorruptFraction > 0 {
+		sampleSize, err := integrity.SampleSize(int64(len(manifest.Checksums)), integritySampleConfidence, integrityCorruptFraction)
+		if err != nil {
+			return nil, err
+		}
+
+		partitions = manifest.Sample(sampleSize, rand.New(rand.NewPCG(uint64(g.GinkgoRandomSeed()), uint64(len(manifest.Checksums)))))
+	}
+
+	framework.By("Verifying integrity of %d out of %d partitions", len(partitions), len(manifest.Checksums))
+	report, err := integrity.Verify(ctx, session, manifest, partitions)
+	if err != nil {
+		return nil, fmt.Errorf("can't verify integrity data: %w", err)
+	}
+	framework.Infof("Verified %d partitions in %v, %d missing and %d corrupt.", report.Checked, report.Duration, len(report.Missing), len(report.Corrupt))
+
+	return &results.Integrity{
+		Partitions:        int64(len(manifest.Checksums)),
+		CheckedPartitions: report.Checked,
+		MissingPartitions: report.Missing,
+		CorruptPartitions: report.Corrupt,
+		VerificationMs:    report.Duration.Milliseconds(),
+	}, nil
+}
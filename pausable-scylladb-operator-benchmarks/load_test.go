@@ -0,0 +1,83 @@
+package pausable_scylladb_operator_benchmarks_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gocql/gocql"
+	g "github.com/onsi/ginkgo/v2"
+	"github.com/pausing-clusters-thesis/benchmarks/load"
+	"github.com/pausing-clusters-thesis/benchmarks/results"
+	"github.com/scylladb/gocqlx/v2"
+)
+
+const (
+	loadKeys           = 10_000
+	loadValueSizeBytes = 1024
+	loadConcurrency    = 32
+
+	// steadyStateWindows is the number of consecutive windows within the tolerance needed to consider the cluster steady.
+	steadyStateWindows = 3
+)
+
+func newLoadGenerator(session gocqlx.Session, consistency gocql.Consistency) (*load.Generator, error) {
+	return load.NewGenerator(session, load.Options{
+		Keyspace:       load.DefaultKeyspace,
+		Consistency:    consistency,
+		Rate:           loadRate,
+		ReadRatio:      loadReadRatio,
+		Keys:           loadKeys,
+		ValueSizeBytes: loadValueSizeBytes,
+		Concurrency:    loadConcurrency,
+		Window:         load.DefaultWindow,
+		Seed:           uint64(g.GinkgoRandomSeed()),
+	})
+}
+
+// measureSteadyState runs the load until both latency and throughput get back to the baseline and returns the times
+// it took, relative to startTime.
+func measureSteadyState(ctx context.Context, session gocqlx.Session, consistency gocql.Consistency, baseline load.Baseline, startTime time.Time) (time.Duration, *results.SteadyState, error) {
+	generator, err := newLoadGenerator(session, consistency)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	tolerance := steadyStateTolerancePercent / 100
+	isLatencySteady := baseline.IsLatencySteady(tolerance)
+	isThroughputSteady := baseline.IsThroughputSteady(tolerance)
+
+	loadStartTime := time.Now()
+	isSteady := load.AllSteady(isLatencySteady, isThroughputSteady)
+	windows, err := generator.Run(ctx, steadyStateTimeout, func(windows []load.Window) bool {
+		_, ok := load.TimeToSteadyState(windows, steadyStateWindows, isSteady)
+		return ok
+	})
+	if err != nil {
+		return 0, nil, fmt.Errorf("can't run load: %w", err)
+	}
+
+	timeToSteadyState, ok := load.TimeToSteadyState(windows, steadyStateWindows, isSteady)
+	if !ok {
+		return 0, nil, fmt.Errorf("cluster didn't get within %v%% of the baseline in %v", steadyStateTolerancePercent, steadyStateTimeout)
+	}
+
+	timeToSteadyLatency, ok := load.TimeToSteadyState(windows, steadyStateWindows, isLatencySteady)
+	if !ok {
+		return 0, nil, fmt.Errorf("p99 latency didn't get within %v%% of the baseline %.2fms in %v", steadyStateTolerancePercent, baseline.P99LatencyMs, steadyStateTimeout)
+	}
+
+	timeToSteadyThroughput, ok := load.TimeToSteadyState(windows, steadyStateWindows, isThroughputSteady)
+	if !ok {
+		return 0, nil, fmt.Errorf("throughput didn't get within %v%% of the baseline %.2f ops/s in %v", steadyStateTolerancePercent, baseline.Throughput, steadyStateTimeout)
+	}
+
+	offset := loadStartTime.Sub(startTime)
+	return offset + timeToSteadyState, &results.SteadyState{
+		BaselineP99LatencyMs:     baseline.P99LatencyMs,
+		BaselineThroughput:       baseline.Throughput,
+		TolerancePercent:         steadyStateTolerancePercent,
+		TimeToSteadyLatencyMs:    (offset + timeToSteadyLatency).Milliseconds(),
+		TimeToSteadyThroughputMs: (offset + timeToSteadyThroughput).Milliseconds(),
+	}, nil
+}
@@ -1,27 +1,32 @@
 package pausable_scylladb_operator_benchmarks_test
 
 import (
-	"bufio"
 	"context"
 	"crypto/sha256"
 	"errors"
 	"flag"
 	"fmt"
-	"io"
 	"os"
-	"path"
-	"regexp"
 	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gocql/gocql"
-	"github.com/gocql/gocql/scyllacloud"
 	g "github.com/onsi/ginkgo/v2"
 	o "github.com/onsi/gomega"
+	"github.com/pausing-clusters-thesis/benchmarks/bootlog"
 	"github.com/pausing-clusters-thesis/benchmarks/framework"
+	"github.com/pausing-clusters-thesis/benchmarks/integrity"
+	"github.com/pausing-clusters-thesis/benchmarks/load"
 	"github.com/pausing-clusters-thesis/benchmarks/naming"
+	"github.com/pausing-clusters-thesis/benchmarks/preload"
+	"github.com/pausing-clusters-thesis/benchmarks/probe"
+	"github.com/pausing-clusters-thesis/benchmarks/results"
+	"github.com/pausing-clusters-thesis/benchmarks/sweep"
+	"github.com/pausing-clusters-thesis/benchmarks/timeline"
 	"github.com/pausing-clusters-thesis/benchmarks/utils"
+	"github.com/pausing-clusters-thesis/benchmarks/workload"
 	pausingv1alpha1 "github.com/pausing-clusters-thesis/pausable-scylladb-operator/pkg/api/pausing/v1alpha1"
 	psocontrollerhelpers "github.com/pausing-clusters-thesis/pausable-scylladb-operator/pkg/controllerhelpers"
 	psonaming "github.com/pausing-clusters-thesis/pausable-scylladb-operator/pkg/naming"
@@ -31,8 +36,6 @@ import (
 	socontrollerhelpers "github.com/scylladb/scylla-operator/pkg/controllerhelpers"
 	"github.com/scylladb/scylla-operator/pkg/genericclioptions"
 	sonaming "github.com/scylladb/scylla-operator/pkg/naming"
-	"github.com/scylladb/scylla-operator/pkg/scheme"
-	cqlclientv1alpha1 "github.com/scylladb/scylla-operator/pkg/scylla/api/cqlclient/v1alpha1"
 	soframework "github.com/scylladb/scylla-operator/test/e2e/framework"
 	sotestutils "github.com/scylladb/scylla-operator/test/e2e/utils"
 	scyllaclusterverification "github.com/scylladb/scylla-operator/test/e2e/utils/verification/scyllacluster"
@@ -40,36 +43,71 @@ import (
 	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/apimachinery/pkg/util/json"
 	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/utils/ptr"
 )
 
-type result struct {
-	ElapsedTimeMs     int64 `json:"elapsed_time_ms"`
-	ApplicationTimeMs int64 `json:"application_time_ms"`
-	OverheadTimeMs    int64 `json:"overhead_time_ms"`
-}
+const (
+	unpauseTimeout = 10 * time.Minute
+)
 
-var (
-	scyllaProcessStartRegex = regexp.MustCompile(`(\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(?:\.\d+)?(?:Z|[+-][0-9]{2}:[0-9]{ 2}))\s+INFO\s+\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(?:,\d+)? starting ScyllaDB\.{3}`)
-	scyllaServingStartRegex = regexp.MustCompile(`(\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(?:\.\d+)?(?:Z|[+-][0-9]{2}:[0-9]{ 2}))\s+INFO\s+\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(?:,\d+)? \[shard \d+:main\] init - serving`)
+const (
+	scyllaDBImage             = "docker.io/scylladb/scylla:6.2.3@sha256:a9d904089abe9a4f8b5b893ebb5b5bf8b5a1bd0dc6658921cf05f89d3712289c"
+	scyllaDBManagerAgentImage = "docker.io/scylladb/scylla-manager-agent:3.4.1@sha256:392ce6d3971ae077cc58b3cd2c7da1e9572f9f76223dfd5e11445c32e7ab0396"
+
+	// maxRackCount is the number of racks that can be named, as racks are named after the region with a letter from
+	// 'a' to 'z' appended, see getRackSpec.
+	maxRackCount = 'z' - 'a' + 1
 )
 
 var (
 	clientConfig = genericclioptions.NewClientConfig("pausable-scylladb-operator-benchmarks")
 
-	topologyZoneLabelValue   string
-	proxyStorageClassName    string
-	backendCSIDriverName     string
-	nodeCount                int
-	ingressClassName         string
-	ingressControllerAddress string
-	destDir                  string
+	topologyZoneLabelValues     string
+	rackCount                   int
+	proxyStorageClassName       string
+	backendCSIDriverName        string
+	nodeCount                   int
+	ingressClassName            string
+	ingressControllerAddress    string
+	destDir                     string
+	resultFormatsString         = results.FormatJSONLines
+	resultCollectorURL          string
+	poolSizesString             = "1:1,0:0"
+	sweepConfigPath             string
+	herdSize                    = 4
+	herdPoolCapacity            = 2
+	preloadSizesString          = "0"
+	replicationFactorsString    = "0"
+	consistenciesString         = gocql.Quorum.String()
+	preloadTables               = 1
+	preloadConcurrency          = preload.DefaultConcurrency
+	storageCapacityString       = "10Gi"
+	soakCycles                  = 0
+	soakPoolCapacity            = 1
+	probeInterval               = probe.DefaultInterval
+	loadRate                    = 0
+	loadReadRatio               = 0.5
+	loadBaselineDuration        = 30 * time.Second
+	steadyStateTolerancePercent = 10.0
+	steadyStateTimeout          = 5 * time.Minute
+	workloadSpecPath            string
+	integrityPartitions         int64
+	integritySampleConfidence   = 0.99
+	integrityCorruptFraction    = 0.0
+
+	runMetadata        *results.Metadata
+	resultSinkOptions  results.SinkOptions
+	poolSizes          []sweep.PoolSize
+	preloadSizes       []resource.Quantity
+	replicationFactors []int
+	consistencies      []gocql.Consistency
+	topologyZones      []string
+	racks              int
+	storageCapacity    resource.Quantity
+	workloadSpec       *workload.Spec
 )
 
 var supportedBackendCSIDriverNames = []string{
@@ -78,13 +116,39 @@ var supportedBackendCSIDriverNames = []string{
 }
 
 func init() {
-	flag.StringVar(&topologyZoneLabelValue, "topology-zone-label-value", topologyZoneLabelValue, "The value of the topology zone label (optional).")
+	flag.StringVar(&topologyZoneLabelValues, "topology-zone-label-values", topologyZoneLabelValues, "Comma-separated list of values of the topology zone label. Racks are spread over the zones in the given order (optional).")
+	flag.StringVar(&topologyZoneLabelValues, "topology-zone-label-value", topologyZoneLabelValues, "Deprecated: use topology-zone-label-values.")
+	flag.IntVar(&rackCount, "racks", rackCount, "The number of racks in the datacenter. Defaults to a rack in each of the topology zones, or a single rack if no zones are given.")
 	flag.StringVar(&proxyStorageClassName, "proxy-storage-class-name", proxyStorageClassName, "The name of a StorageClass provisioned by the Proxy CSI Driver to be used in the test.")
 	flag.StringVar(&backendCSIDriverName, "backend-csi-driver-name", backendCSIDriverName, fmt.Sprintf("The name of the backend CSI driver to test. Supported drvier names are: %v.", supportedBackendCSIDriverNames))
-	flag.IntVar(&nodeCount, "nodes", nodeCount, "The number of nodes in the cluster.")
+	flag.IntVar(&nodeCount, "nodes", nodeCount, "The number of nodes in each rack.")
 	flag.StringVar(&ingressClassName, "ingress-class-name", ingressClassName, "Name of the IngressClass to use to configure CQL backends.")
 	flag.StringVar(&ingressControllerAddress, "ingress-controller-address", ingressControllerAddress, "Overrides destination address when sending testing data to applications behind ingresses.")
 	flag.StringVar(&destDir, "dest-dir", destDir, "Destination directory in which results should be saved.")
+	flag.StringVar(&resultFormatsString, "result-formats", resultFormatsString, fmt.Sprintf("Comma-separated list of formats in which results should be saved in dest-dir. Supported formats are: %v.", results.SupportedFormats))
+	flag.StringVar(&resultCollectorURL, "result-collector-url", resultCollectorURL, "URL of a collector to which results should be additionally sent in POST requests (optional).")
+	flag.StringVar(&poolSizesString, "pool-sizes", poolSizesString, "Comma-separated list of CAPACITY:LIMIT pairs of ScyllaDBDatacenterPools to measure unpausing with.")
+	flag.StringVar(&sweepConfigPath, "sweep-config", sweepConfigPath, "Path to a YAML file with the scenarios to sweep through. Pool sizes in the file take precedence over pool-sizes (optional).")
+	flag.IntVar(&herdSize, "herd-size", herdSize, "The number of PausableScyllaDBDatacenters unpaused together in the concurrent unpause scenario.")
+	flag.IntVar(&herdPoolCapacity, "herd-pool-capacity", herdPoolCapacity, "The capacity of the ScyllaDBDatacenterPool in the concurrent unpause scenario. It must be less than herd-size.")
+	flag.StringVar(&preloadSizesString, "preload-sizes", preloadSizesString, "Comma-separated list of amounts of data, e.g. 1Gi, written to the cluster before pausing it. Preload sizes in sweep-config take precedence.")
+	flag.StringVar(&replicationFactorsString, "replication-factors", replicationFactorsString, "Comma-separated list of replication factors of the keyspaces written to in the test. Zero stands for a replica on every node. Replication factors in sweep-config take precedence.")
+	flag.StringVar(&consistenciesString, "consistency-levels", consistenciesString, "Comma-separated list of consistency levels, e.g. ONE,QUORUM,ALL,LOCAL_QUORUM, of the verification traffic and availability probes. Consistency levels in sweep-config take precedence.")
+	flag.IntVar(&preloadTables, "preload-tables", preloadTables, "The number of tables the preloaded data is spread across.")
+	flag.IntVar(&preloadConcurrency, "preload-concurrency", preloadConcurrency, "The number of concurrent writes used to preload data.")
+	flag.IntVar(&soakCycles, "soak-cycles", soakCycles, "The number of pause and unpause cycles in the soak scenario. The scenario is skipped if zero.")
+	flag.IntVar(&soakPoolCapacity, "soak-pool-capacity", soakPoolCapacity, "The capacity of the ScyllaDBDatacenterPool in the soak scenario.")
+	flag.DurationVar(&probeInterval, "probe-interval", probeInterval, "The interval between CQL availability probes while unpausing.")
+	flag.IntVar(&loadRate, "load-rate", loadRate, "The number of operations per second of the load used to measure time to steady state. Zero disables the load.")
+	flag.Float64Var(&loadReadRatio, "load-read-ratio", loadReadRatio, "The fraction of reads in the load used to measure time to steady state.")
+	flag.DurationVar(&loadBaselineDuration, "load-baseline-duration", loadBaselineDuration, "How long the load runs before pausing to set the latency and throughput baseline.")
+	flag.Float64Var(&steadyStateTolerancePercent, "steady-state-tolerance-percent", steadyStateTolerancePercent, "How far, in percent, p99 latency and throughput may be from the baseline for the cluster to be considered steady.")
+	flag.DurationVar(&steadyStateTimeout, "steady-state-timeout", steadyStateTimeout, "How long to wait for the cluster to get back to the baseline after unpausing.")
+	flag.StringVar(&workloadSpecPath, "workload-spec", workloadSpecPath, "Path to a YAML file with a CQL workload run right after unpausing to measure operation latencies (optional).")
+	flag.Int64Var(&integrityPartitions, "integrity-partitions", integrityPartitions, "The number of partitions with checksummed data written before pausing and verified after unpausing. Zero disables the verification.")
+	flag.Float64Var(&integritySampleConfidence, "integrity-sample-confidence", integritySampleConfidence, "The confidence of finding missing or corrupt partitions when verifying a random sample of them.")
+	flag.Float64Var(&integrityCorruptFraction, "integrity-corrupt-fraction", integrityCorruptFraction, "The smallest fraction of missing or corrupt partitions the random sample has to find with integrity-sample-confidence. Zero verifies all partitions.")
+	flag.StringVar(&storageCapacityString, "storage-capacity", storageCapacityString, "The storage capacity of each ScyllaDB node. It must fit the largest preload size, as every node holds a replica of all data.")
 }
 
 func TestPausableScylladbOperatorBenchmarks(t *testing.T) {
@@ -121,6 +185,22 @@ func Validate() error {
 		errs = append(errs, fmt.Errorf("nodes must not be less or equal to zero"))
 	}
 
+	topologyZones = nil
+	for _, zone := range strings.Split(topologyZoneLabelValues, ",") {
+		zone = strings.TrimSpace(zone)
+		if len(zone) != 0 {
+			topologyZones = append(topologyZones, zone)
+		}
+	}
+
+	racks = rackCount
+	if racks == 0 {
+		racks = max(len(topologyZones), 1)
+	}
+	if rackCount < 0 || racks > maxRackCount {
+		errs = append(errs, fmt.Errorf("racks must be between 0 and %d, got %d", maxRackCount, racks))
+	}
+
 	if len(ingressControllerAddress) == 0 {
 		errs = append(errs, fmt.Errorf("ingress-class-name can't be empty"))
 	}
@@ -140,6 +220,147 @@ func Validate() error {
 		errs = append(errs, fmt.Errorf("dest-dir can't be empty"))
 	}
 
+	resultFormats, err := results.ParseFormats(resultFormatsString)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("invalid result-formats: %w", err))
+	} else {
+		resultSinkOptions = results.SinkOptions{
+			Formats:      resultFormats,
+			DestDir:      destDir,
+			CollectorURL: resultCollectorURL,
+		}
+
+		err = resultSinkOptions.Validate()
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if herdPoolCapacity < 0 || herdPoolCapacity >= herdSize {
+		errs = append(errs, fmt.Errorf("herd-pool-capacity must be between zero and herd-size %d (exclusive), got %d", herdSize, herdPoolCapacity))
+	}
+
+	if probeInterval <= 0 {
+		errs = append(errs, fmt.Errorf("probe-interval must be greater than zero, got %v", probeInterval))
+	}
+
+	if loadRate < 0 {
+		errs = append(errs, fmt.Errorf("load-rate must not be negative, got %d", loadRate))
+	}
+
+	if loadReadRatio < 0 || loadReadRatio > 1 {
+		errs = append(errs, fmt.Errorf("load-read-ratio must be between 0 and 1, got %v", loadReadRatio))
+	}
+
+	if loadBaselineDuration < steadyStateWindows*load.DefaultWindow {
+		errs = append(errs, fmt.Errorf("load-baseline-duration must be at least %v, got %v", steadyStateWindows*load.DefaultWindow, loadBaselineDuration))
+	}
+
+	if steadyStateTolerancePercent < 0 {
+		errs = append(errs, fmt.Errorf("steady-state-tolerance-percent must not be negative, got %v", steadyStateTolerancePercent))
+	}
+
+	if steadyStateTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("steady-state-timeout must be greater than zero, got %v", steadyStateTimeout))
+	}
+
+	if len(workloadSpecPath) != 0 {
+		workloadSpec, err = workload.ReadSpecFile(workloadSpecPath)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid workload-spec: %w", err))
+		}
+	}
+
+	if integrityPartitions < 0 {
+		errs = append(errs, fmt.Errorf("integrity-partitions must not be negative, got %d", integrityPartitions))
+	}
+
+	if integritySampleConfidence <= 0 || integritySampleConfidence >= 1 {
+		errs = append(errs, fmt.Errorf("integrity-sample-confidence must be between 0 and 1 (exclusive), got %v", integritySampleConfidence))
+	}
+
+	if integrityCorruptFraction < 0 || integrityCorruptFraction > 1 {
+		errs = append(errs, fmt.Errorf("integrity-corrupt-fraction must be between 0 and 1, got %v", integrityCorruptFraction))
+	}
+
+	if soakCycles < 0 {
+		errs = append(errs, fmt.Errorf("soak-cycles must not be negative, got %d", soakCycles))
+	}
+
+	if soakPoolCapacity < 0 {
+		errs = append(errs, fmt.Errorf("soak-pool-capacity must not be negative, got %d", soakPoolCapacity))
+	}
+
+	poolSizes, err = sweep.ParsePoolSizes(poolSizesString)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("invalid pool-sizes: %w", err))
+	}
+
+	preloadSizes, err = sweep.ParseDataSizes(preloadSizesString)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("invalid preload-sizes: %w", err))
+	}
+
+	replicationFactors, err = sweep.ParseReplicationFactors(replicationFactorsString)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("invalid replication-factors: %w", err))
+	}
+
+	consistencies, err = sweep.ParseConsistencies(consistenciesString)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("invalid consistency-levels: %w", err))
+	}
+
+	if len(sweepConfigPath) > 0 {
+		sweepConfig, err := sweep.ReadConfigFile(sweepConfigPath)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			if sweepConfig.PoolSizes != nil {
+				poolSizes = sweepConfig.PoolSizes
+			}
+
+			if sweepConfig.PreloadSizes != nil {
+				preloadSizes = sweepConfig.PreloadSizes
+			}
+
+			if sweepConfig.ReplicationFactors != nil {
+				replicationFactors = sweepConfig.ReplicationFactors
+			}
+
+			if sweepConfig.Consistencies != nil {
+				consistencies = sweepConfig.Consistencies
+			}
+		}
+	}
+
+	// The size of the datacenter is only known once both the rack and node counts are valid.
+	if nodeCount > 0 && racks > 0 && racks <= maxRackCount {
+		err = sweep.ValidateReplicationFactorsForNodes(replicationFactors, racks*nodeCount)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid replication-factors: %w", err))
+		}
+	}
+
+	if preloadTables <= 0 {
+		errs = append(errs, fmt.Errorf("preload-tables must be greater than zero, got %d", preloadTables))
+	}
+
+	if preloadConcurrency <= 0 {
+		errs = append(errs, fmt.Errorf("preload-concurrency must be greater than zero, got %d", preloadConcurrency))
+	}
+
+	storageCapacity, err = resource.ParseQuantity(storageCapacityString)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("invalid storage-capacity: %w", err))
+	} else {
+		for _, size := range preloadSizes {
+			if size.Cmp(storageCapacity) >= 0 {
+				errs = append(errs, fmt.Errorf("preload size %q doesn't fit in storage-capacity %q", size.String(), storageCapacity.String()))
+			}
+		}
+	}
+
 	return errors.Join(errs...)
 }
 
@@ -151,6 +372,11 @@ func Complete() error {
 		return err
 	}
 
+	runMetadata, err = results.NewMetadata(flag.CommandLine, g.GinkgoRandomSeed(), []string{scyllaDBImage, scyllaDBManagerAgentImage})
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -158,16 +384,52 @@ var _ = g.Describe("measure time to readiness", func() {
 	f := framework.NewFramework("benchmark")
 
 	type scenarioEntry struct {
-		capacity        int32
-		limit           int32
-		resultsFileName string
+		capacity             int32
+		limit                int32
+		preloadSize          resource.Quantity
+		replicationFactor    int
+		consistency          gocql.Consistency
+		resultsFileName      string
+		pauseResultsFileName string
+	}
+
+	var scenarioEntries []g.TableEntry
+	for _, ps := range poolSizes {
+		for _, preloadSize := range preloadSizes {
+			for _, rf := range replicationFactors {
+				for _, consistency := range consistencies {
+					description := fmt.Sprintf("with a ScyllaDBDatacenterPool of capacity %d and limit %d", ps.Capacity, ps.Limit)
+					if !preloadSize.IsZero() {
+						description += fmt.Sprintf(" and %s of preloaded data", preloadSize.String())
+					}
+					if rf != 0 {
+						description += fmt.Sprintf(" and replication factor %d", rf)
+					}
+					description += fmt.Sprintf(" at consistency level %v", consistency)
+
+					resultsFileName := ps.ResultsName() + sweep.DataSizeResultsSuffix(preloadSize) + sweep.ReplicationFactorResultsSuffix(rf) + sweep.ConsistencyResultsSuffix(consistency)
+					scenarioEntries = append(scenarioEntries, g.Entry(description, &scenarioEntry{
+						capacity:             ps.Capacity,
+						limit:                ps.Limit,
+						preloadSize:          preloadSize,
+						replicationFactor:    rf,
+						consistency:          consistency,
+						resultsFileName:      resultsFileName,
+						pauseResultsFileName: resultsFileName + "-pause",
+					}))
+				}
+			}
+		}
 	}
 
 	g.DescribeTable("when unpausing PausableScyllaDBDatacenter", func(ctx g.SpecContext, se *scenarioEntry) {
-		resultsFilePath := path.Join(destDir, se.resultsFileName)
-		resultsFile, err := os.OpenFile(resultsFilePath, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0644)
+		resultSink, err := results.NewSink(resultSinkOptions, se.resultsFileName)
+		o.Expect(err).NotTo(o.HaveOccurred())
+		g.DeferCleanup(resultSink.Close)
+
+		pauseResultSink, err := results.NewSink(resultSinkOptions, se.pauseResultsFileName)
 		o.Expect(err).NotTo(o.HaveOccurred())
-		g.DeferCleanup(resultsFile.Close)
+		g.DeferCleanup(pauseResultSink.Close)
 
 		c := f.Cluster(0)
 		ns, nsClient, ok := c.DefaultNamespaceIfAny()
@@ -187,35 +449,7 @@ var _ = g.Describe("measure time to readiness", func() {
 		}, backendImmediateStorageClass)
 
 		framework.By("Creating a ScyllaDBDatacenterPool")
-		sdcp := &pausingv1alpha1.ScyllaDBDatacenterPool{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: "basic",
-			},
-			Spec: pausingv1alpha1.ScyllaDBDatacenterPoolSpec{
-				Template: pausingv1alpha1.ScyllaDBDatacenterTemplate{
-					Spec: getScyllaDBDatacenterSpec(backendImmediateStorageClass.GetName()),
-				},
-				Capacity:              se.capacity,
-				Limit:                 se.limit,
-				ProxyStorageClassName: proxyStorageClassName,
-			},
-		}
-
-		sdcp.Spec.Template.Spec.CertificateOptions = &scyllav1alpha1.CertificateOptions{
-			ServingCA: &scyllav1alpha1.TLSCertificateAuthority{
-				Type: scyllav1alpha1.TLSCertificateAuthorityTypeUserManaged,
-				UserManagedOptions: &scyllav1alpha1.UserManagedTLSCertificateAuthorityOptions{
-					SecretName: "",
-				},
-			},
-			ClientCA: &scyllav1alpha1.TLSCertificateAuthority{
-				Type: scyllav1alpha1.TLSCertificateAuthorityTypeUserManaged,
-				UserManagedOptions: &scyllav1alpha1.UserManagedTLSCertificateAuthorityOptions{
-					SecretName: "",
-				},
-			},
-		}
-
+		sdcp := getScyllaDBDatacenterPool("basic", backendImmediateStorageClass.GetName(), se.capacity, se.limit)
 		sdcp, err = c.PausingAdminClient().PausingV1alpha1().ScyllaDBDatacenterPools(ns.GetName()).Create(ctx, sdcp, metav1.CreateOptions{})
 		o.Expect(err).NotTo(o.HaveOccurred())
 
@@ -225,23 +459,7 @@ var _ = g.Describe("measure time to readiness", func() {
 		o.Expect(err).NotTo(o.HaveOccurred())
 
 		framework.By("Creating a PausableScyllaDBDatacenter in an unpaused state")
-		psdc := &pausingv1alpha1.PausableScyllaDBDatacenter{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: "basic",
-			},
-			Spec: pausingv1alpha1.PausableScyllaDBDatacenterSpec{
-				ScyllaDBDatacenterPoolName: sdcp.GetName(),
-				Paused:                     ptr.To(false),
-				ExposeOptions: &pausingv1alpha1.ExposeOptions{
-					CQL: &scyllav1alpha1.CQLExposeOptions{
-						Ingress: &scyllav1alpha1.CQLExposeIngressOptions{
-							IngressClassName: ingressClassName,
-						},
-					},
-				},
-			},
-		}
-
+		psdc := getPausableScyllaDBDatacenter("basic", sdcp.GetName())
 		psdc, err = c.PausingAdminClient().PausingV1alpha1().PausableScyllaDBDatacenters(ns.GetName()).Create(ctx, psdc, metav1.CreateOptions{})
 		o.Expect(err).NotTo(o.HaveOccurred())
 
@@ -260,8 +478,6 @@ var _ = g.Describe("measure time to readiness", func() {
 
 		sdc, err := c.ScyllaAdminClient().ScyllaV1alpha1().ScyllaDBDatacenters(ns.GetName()).Get(ctx, sdcName, metav1.GetOptions{})
 		o.Expect(err).NotTo(o.HaveOccurred())
-		o.Expect(sdc.Spec.DNSDomains).To(o.HaveLen(1))
-		dnsDomain := sdc.Spec.DNSDomains[0]
 
 		connectionBundleDir, err := os.MkdirTemp(os.TempDir(), fmt.Sprintf("connection-bundle-%s-", ns.GetName()))
 		o.Expect(err).NotTo(o.HaveOccurred())
@@ -270,61 +486,90 @@ var _ = g.Describe("measure time to readiness", func() {
 			o.Expect(err).NotTo(o.HaveOccurred())
 		}()
 
-		var groupVersioner runtime.GroupVersioner = schema.GroupVersions([]schema.GroupVersion{cqlclientv1alpha1.GroupVersion})
-		decoder := scheme.Codecs.DecoderToVersion(scheme.Codecs.UniversalDeserializer(), groupVersioner)
-		encoder := scheme.Codecs.EncoderForVersion(scheme.DefaultYamlSerializer, groupVersioner)
-
 		framework.By("Injecting ingress controller address into the CQL connection bundle")
-		bundleSecret, err := nsClient.KubeClient().CoreV1().Secrets(sdc.Namespace).Get(ctx, sonaming.GetScyllaClusterLocalAdminCQLConnectionConfigsName(sdc.Name), metav1.GetOptions{})
-		o.Expect(err).NotTo(o.HaveOccurred())
-
-		cqlConnectionConfig := &cqlclientv1alpha1.CQLConnectionConfig{}
-		_, _, err = decoder.Decode(bundleSecret.Data[dnsDomain], nil, cqlConnectionConfig)
-		o.Expect(err).NotTo(o.HaveOccurred())
-
-		gossipDatacenterName := sonaming.GetScyllaDBDatacenterGossipDatacenterName(sdc)
-		cqlConnectionConfig.Datacenters[gossipDatacenterName].Server = ingressControllerAddress
-
-		cqlConnectionConfigData, err := runtime.Encode(encoder, cqlConnectionConfig)
-		o.Expect(err).NotTo(o.HaveOccurred())
-
-		cqlConnectionConfigFilePath := path.Join(connectionBundleDir, fmt.Sprintf("%s.yaml", dnsDomain))
-		framework.By("Saving CQL Connection Config in %s", cqlConnectionConfigFilePath)
-		err = os.WriteFile(cqlConnectionConfigFilePath, cqlConnectionConfigData, 0600)
+		cqlConnectionConfigFilePath, err := writeCQLConnectionConfig(ctx, c, sdc, connectionBundleDir)
 		o.Expect(err).NotTo(o.HaveOccurred())
 
 		framework.By("Connecting to cluster via Ingress")
-		cluster, err := scyllacloud.NewCloudCluster(cqlConnectionConfigFilePath)
+		cluster, err := newCloudCluster(cqlConnectionConfigFilePath)
 		o.Expect(err).NotTo(o.HaveOccurred())
-
-		// Increase default timeout, due to additional hop on the route to host.
-		cluster.Timeout = 10 * time.Second
-		cluster.Logger = nopLogger{}
-		cluster.Consistency = gocql.Quorum
+		cluster.Consistency = se.consistency
 
 		session, err := gocqlx.WrapSession(cluster.CreateSession())
 		o.Expect(err).NotTo(o.HaveOccurred())
 
+		replicationFactor := se.replicationFactor
+		if replicationFactor == 0 {
+			replicationFactor = len(getRackMembers(sdc))
+		}
+
 		// When overriding the sessions, "hosts" are only used by the data inserter to determine a replication factor.
-		hosts := slices.Repeat([]string{""}, nodeCount)
+		hosts := slices.Repeat([]string{""}, replicationFactor)
 
 		di, err := sotestutils.NewDataInserter(hosts, sotestutils.WithSession(&session))
 		o.Expect(err).NotTo(o.HaveOccurred())
 
 		scyllaclusterverification.InsertAndVerifyCQLDataUsingDataInserter(ctx, di)
+
+		framework.By("Preparing the CQL availability probe")
+		err = probe.Prepare(ctx, session.Session, probe.DefaultKeyspace, len(hosts))
+		o.Expect(err).NotTo(o.HaveOccurred())
+
+		if !se.preloadSize.IsZero() {
+			framework.By("Preloading %s of data into %d table(s)", se.preloadSize.String(), preloadTables)
+			preloadResult, err := preload.Run(ctx, session.Session, preload.Options{
+				Keyspace:          preload.DefaultKeyspace,
+				SizeBytes:         se.preloadSize.Value(),
+				Tables:            preloadTables,
+				RowSizeBytes:      preload.DefaultRowSizeBytes,
+				Concurrency:       preloadConcurrency,
+				ReplicationFactor: len(hosts),
+				Seed:              uint64(g.GinkgoRandomSeed()),
+			})
+			o.Expect(err).NotTo(o.HaveOccurred())
+			framework.Infof("Preloaded %d rows (%d bytes) in %v.", preloadResult.Rows, preloadResult.Bytes, preloadResult.Duration)
+		}
+
+		var integrityManifest *integrity.Manifest
+		if integrityPartitions > 0 {
+			integrityManifest, err = writeIntegrityData(ctx, session.Session, len(hosts))
+			o.Expect(err).NotTo(o.HaveOccurred())
+		}
+
+		var loadBaseline load.Baseline
+		if loadRate > 0 {
+			framework.By("Running load for %v to set a latency and throughput baseline", loadBaselineDuration)
+			err = load.Prepare(ctx, session, load.DefaultKeyspace, len(hosts))
+			o.Expect(err).NotTo(o.HaveOccurred())
+
+			generator, err := newLoadGenerator(session, se.consistency)
+			o.Expect(err).NotTo(o.HaveOccurred())
+
+			windows, err := generator.Run(ctx, loadBaselineDuration, nil)
+			o.Expect(err).NotTo(o.HaveOccurred())
+
+			loadBaseline = load.NewBaseline(windows)
+			framework.Infof("Baseline p99 latency is %.2fms and throughput is %.2f ops/s.", loadBaseline.P99LatencyMs, loadBaseline.Throughput)
+		}
+
+		if workloadSpec != nil {
+			framework.By("Preparing the schema of the workload")
+			err = workload.Prepare(ctx, session, workloadSpec.Schema)
+			o.Expect(err).NotTo(o.HaveOccurred())
+		}
+
 		session.Close()
 		di.ForceSession(nil)
 
 		framework.By("Getting VolumeAttachments for backend PVCs")
 		var backendVAs []*storagev1.VolumeAttachment
-		o.Expect(sdcp.Spec.Template.Spec.Racks).To(o.HaveLen(1))
-		for i := range nodeCount {
-			podName := sonaming.MemberServiceName(sdcp.Spec.Template.Spec.Racks[0], sdc, i)
+		for _, m := range getRackMembers(sdc) {
+			podName := sonaming.MemberServiceName(m.rack, sdc, int(m.idx))
 			pod, err := nsClient.KubeClient().CoreV1().Pods(ns.GetName()).Get(ctx, podName, metav1.GetOptions{})
 			o.Expect(err).NotTo(o.HaveOccurred())
 			o.Expect(pod.Spec.NodeName).NotTo(o.BeEmpty())
 
-			backendPVCName := psonaming.GetBackendPersistentVolumeClaimNameForPausableScyllaDBDatacenterMember(psdc.Name, sdcp.Spec.Template.Spec.Racks[0].Name, int32(i))
+			backendPVCName := psonaming.GetBackendPersistentVolumeClaimNameForPausableScyllaDBDatacenterMember(psdc.Name, m.rack.Name, m.idx)
 			backendPVC, err := nsClient.KubeClient().CoreV1().PersistentVolumeClaims(ns.GetName()).Get(ctx, backendPVCName, metav1.GetOptions{})
 			o.Expect(err).NotTo(o.HaveOccurred())
 			o.Expect(backendPVC.Spec.VolumeName).NotTo(o.BeEmpty())
@@ -341,6 +586,7 @@ var _ = g.Describe("measure time to readiness", func() {
 		}
 
 		framework.By("Pausing PausableScyllaDBDatacenter")
+		pauseStopwatch := timeline.NewStopwatch(time.Now())
 		psdc, err = c.PausingAdminClient().PausingV1alpha1().PausableScyllaDBDatacenters(ns.GetName()).Patch(
 			ctx,
 			psdc.Name,
@@ -356,6 +602,7 @@ var _ = g.Describe("measure time to readiness", func() {
 		// TODO: context
 		psdc, err = psocontrollerhelpers.WaitForPausableScyllaDBDatacenterState(ctx, c.PausingAdminClient().PausingV1alpha1().PausableScyllaDBDatacenters(ns.GetName()), psdc.GetName(), socontrollerhelpers.WaitForStateOptions{}, psocontrollerhelpers.IsPausableScyllaDBDatacenterRolledOut)
 		o.Expect(err).NotTo(o.HaveOccurred())
+		pauseStopwatch.Lap(pausedRolledOutMilestone)
 		// TODO: verify
 
 		framework.By("Wait for ScyllaDBDatacenter to be deleted")
@@ -369,14 +616,15 @@ var _ = g.Describe("measure time to readiness", func() {
 			ptr.To(sdc.GetUID()),
 		)
 		o.Expect(err).NotTo(o.HaveOccurred())
+		pauseStopwatch.Lap(scyllaDBDatacenterDeletedMilestone)
 
 		framework.By("Waiting for backend PVCs to be unbound from proxy PVCs")
-		o.Expect(sdcp.Spec.Template.Spec.Racks).To(o.HaveLen(1))
-		for i := range int32(nodeCount) {
-			backendPVCName := psonaming.GetBackendPersistentVolumeClaimNameForPausableScyllaDBDatacenterMember(psdc.Name, sdcp.Spec.Template.Spec.Racks[0].Name, i)
+		for _, m := range getRackMembers(sdc) {
+			backendPVCName := psonaming.GetBackendPersistentVolumeClaimNameForPausableScyllaDBDatacenterMember(psdc.Name, m.rack.Name, m.idx)
 			_, err = socontrollerhelpers.WaitForPVCState(ctx, nsClient.KubeClient().CoreV1().PersistentVolumeClaims(ns.GetName()), backendPVCName, socontrollerhelpers.WaitForStateOptions{}, IsBackendPersistentVolumeClaimUnboundFromProxyPersistentVolumeClaim)
 			o.Expect(err).NotTo(o.HaveOccurred())
 		}
+		pauseStopwatch.Lap(backendVolumesUnboundMilestone)
 
 		framework.By("Waiting for backend VolumeAttachments to be deleted")
 		for _, va := range backendVAs {
@@ -390,24 +638,143 @@ var _ = g.Describe("measure time to readiness", func() {
 			)
 			o.Expect(err).NotTo(o.HaveOccurred())
 		}
+		pauseStopwatch.Lap(backendVolumeAttachmentsDeletedMilestone)
+
+		pausePhases := pauseStopwatch.Phases()
+		for _, phase := range pausePhases {
+			framework.Infof("Pause phase %q took %dms, reached after %dms.", phase.Name, phase.DurationMs, phase.OffsetMs)
+		}
+
+		pauseRes := results.NewRecord(runMetadata, se.pauseResultsFileName, pauseStopwatch.StartTime(), pauseStopwatch.LastTime())
+		pauseRes.Phases = pausePhases
+		pauseRes.DataSizeBytes = se.preloadSize.Value()
+		pauseRes.ReplicationFactor = replicationFactor
+		pauseRes.Consistency = se.consistency.String()
+		framework.Infof("Pausing took %dms.", pauseRes.ElapsedTimeMs)
+		err = pauseResultSink.Write(ctx, pauseRes)
+		o.Expect(err).NotTo(o.HaveOccurred())
 
 		framework.By("Waiting for ScyllaDBDatacenterPool to roll out")
 		// TODO: context
 		sdcp, err = psocontrollerhelpers.WaitForScyllaDBDatacenterPoolState(ctx, c.PausingAdminClient().PausingV1alpha1().ScyllaDBDatacenterPools(ns.GetName()), sdcp.GetName(), socontrollerhelpers.WaitForStateOptions{}, psocontrollerhelpers.IsScyllaDBDatacenterPoolRolledOut)
 		o.Expect(err).NotTo(o.HaveOccurred())
 
+		unpauseTrackerCtx, unpauseTrackerCtxCancel := context.WithTimeout(ctx, unpauseTimeout)
+		defer unpauseTrackerCtxCancel()
+		observer, err := newUnpauseObserver(unpauseTrackerCtx, c, ns.GetName(), psdc)
+		o.Expect(err).NotTo(o.HaveOccurred())
+		unpauseTracker := timeline.NewTracker(timeline.DefaultPollInterval, observer.milestones()...)
+		unpauseTrackerErrCh := make(chan error, 1)
+		go func() {
+			defer g.GinkgoRecover()
+			unpauseTrackerErrCh <- unpauseTracker.Run(unpauseTrackerCtx)
+		}()
+
+		prober, err := probe.NewProber(cluster, probe.Options{
+			Keyspace:    probe.DefaultKeyspace,
+			Consistency: se.consistency,
+			Interval:    probeInterval,
+			OnAttempt: func(a probe.Attempt) {
+				if a.Succeeded() {
+					framework.Infof("Probe started at %v succeeded after %v.", a.StartTime, a.Duration)
+					return
+				}
+				framework.Infof("Probe started at %v failed in stage %q after %v with a %q error: %v.", a.StartTime, a.Stage, a.Duration, a.ErrorClass, a.Err)
+			},
+		})
+		o.Expect(err).NotTo(o.HaveOccurred())
+		proberCtx, proberCtxCancel := context.WithTimeout(ctx, unpauseTimeout)
+		defer proberCtxCancel()
+		type proberResult struct {
+			report *probe.Report
+			err    error
+		}
+		proberResultCh := make(chan proberResult, 1)
+
+		tracedCluster, connectionTracer, err := newTracedCluster(cluster, cqlConnectionConfigFilePath)
+		o.Expect(err).NotTo(o.HaveOccurred())
+
 		framework.By("Connecting to the paused cluster via Ingress")
 		startTime := time.Now()
-		newSession, err := gocqlx.WrapSession(cluster.CreateSession())
+		go func() {
+			defer g.GinkgoRecover()
+			report, err := prober.Run(proberCtx)
+			proberResultCh <- proberResult{report: report, err: err}
+		}()
+		newSession, err := gocqlx.WrapSession(tracedCluster.CreateSession())
 		stopTime := time.Now()
 		framework.By("Session created successfully")
 		o.Expect(err).NotTo(o.HaveOccurred())
 
+		type steadyStateResult struct {
+			timeToSteadyState time.Duration
+			steadyState       *results.SteadyState
+			err               error
+		}
+		steadyStateResultCh := make(chan steadyStateResult, 1)
+		if loadRate > 0 {
+			go func() {
+				defer g.GinkgoRecover()
+				timeToSteadyState, steadyState, err := measureSteadyState(ctx, newSession, se.consistency, loadBaseline, startTime)
+				steadyStateResultCh <- steadyStateResult{timeToSteadyState: timeToSteadyState, steadyState: steadyState, err: err}
+			}()
+		}
+
+		framework.By("Waiting for the CQL availability probe to write with %v consistency", se.consistency)
+		pr := <-proberResultCh
+		o.Expect(pr.err).NotTo(o.HaveOccurred())
+		availability := getAvailability(startTime, pr.report)
+		framework.Infof("First probe succeeded after %dms and first %v write after %dms, with %d attempt(s) and errors: %v.", availability.TimeToFirstSuccessMs, se.consistency, availability.TimeToFirstWriteMs, availability.Attempts, availability.Errors)
+
+		framework.By("Waiting for all unpause milestones to be observed")
+		err = <-unpauseTrackerErrCh
+		o.Expect(err).NotTo(o.HaveOccurred())
+
+		unpausePhases, err := unpauseTracker.Phases(startTime)
+		o.Expect(err).NotTo(o.HaveOccurred())
+		for _, phase := range unpausePhases {
+			framework.Infof("Unpause phase %q took %dms, reached after %dms.", phase.Name, phase.DurationMs, phase.OffsetMs)
+		}
+
 		di.ForceSession(&newSession)
 
 		scyllaclusterverification.VerifyCQLData(ctx, di)
+
+		var ssr steadyStateResult
+		if loadRate > 0 {
+			framework.By("Waiting for latency and throughput to get back to the baseline")
+			ssr = <-steadyStateResultCh
+			o.Expect(ssr.err).NotTo(o.HaveOccurred())
+			framework.Infof("Cluster got back to steady state after %v (p99 latency after %dms, throughput after %dms).", ssr.timeToSteadyState, ssr.steadyState.TimeToSteadyLatencyMs, ssr.steadyState.TimeToSteadyThroughputMs)
+		}
+
+		// The integrity is only verified once the load stopped, so that its reads don't slow down getting back to the baseline.
+		var integrityResult *results.Integrity
+		if integrityManifest != nil {
+			integrityResult, err = verifyIntegrity(ctx, newSession.Session, integrityManifest)
+			o.Expect(err).NotTo(o.HaveOccurred())
+		}
+
+		var latencies map[string]results.LatencySummary
+		if workloadSpec != nil {
+			framework.By("Running the workload for %v", workloadSpec.Duration.Duration)
+			workloadResult, err := workload.Run(ctx, newSession, *workloadSpec, workload.Options{
+				Seed: uint64(g.GinkgoRandomSeed()),
+			})
+			o.Expect(err).NotTo(o.HaveOccurred())
+			latencies = map[string]results.LatencySummary{
+				string(workload.ReadOperationType):  workloadResult.Reads.Summary(),
+				string(workload.WriteOperationType): workloadResult.Writes.Summary(),
+			}
+			framework.Infof("Workload ran %d operation(s) at %.2f ops/s with %d error(s).", workloadResult.Operations(), workloadResult.Throughput(), workloadResult.Errors)
+		}
 		newSession.Close()
 
+		connections := getHostConnections(startTime, connectionTracer.Hosts())
+		for _, hc := range connections {
+			framework.Infof("Connected to host %q at %q after %dms (TCP %dms, TLS %dms, %d failed attempt(s)), first query completed after %dms.", hc.HostID, hc.Address, hc.ConnectOffsetMs, hc.TCPMs, hc.TLSMs, hc.FailedConnects, hc.FirstQueryOffsetMs)
+		}
+
 		framework.By("Waiting for PausableScyllaDBDatacenter to roll out")
 		// TODO: context
 		psdc, err = psocontrollerhelpers.WaitForPausableScyllaDBDatacenterState(ctx, c.PausingAdminClient().PausingV1alpha1().PausableScyllaDBDatacenters(ns.GetName()), psdc.GetName(), socontrollerhelpers.WaitForStateOptions{}, psocontrollerhelpers.IsPausableScyllaDBDatacenterRolledOut)
@@ -423,40 +790,61 @@ var _ = g.Describe("measure time to readiness", func() {
 		sdc, err = c.ScyllaAdminClient().ScyllaV1alpha1().ScyllaDBDatacenters(ns.GetName()).Get(ctx, sdcName, metav1.GetOptions{})
 		o.Expect(err).NotTo(o.HaveOccurred())
 
-		scyllaStartToServeTime := getScyllaContainerTimeToServeCQL(ctx, nsClient.KubeClient().CoreV1().Pods(ns.GetName()), sdc)
-		o.Expect(scyllaStartToServeTime).NotTo(o.BeZero())
-
-		totalTime := stopTime.Sub(startTime)
-		platformTime := totalTime - scyllaStartToServeTime
-		framework.Infof("Total time: %v.\nPlatform time: %v.\nApplication time: %v.\n", totalTime, platformTime, scyllaStartToServeTime)
+		nodeTimings := getScyllaNodeTimings(ctx, nsClient.KubeClient().CoreV1().Pods(ns.GetName()), sdc)
+		o.Expect(nodeTimings).NotTo(o.BeEmpty())
 
-		jsonEncoder := json.NewEncoder(resultsFile)
-		res := result{
-			ElapsedTimeMs:     totalTime.Milliseconds(),
-			ApplicationTimeMs: scyllaStartToServeTime.Milliseconds(),
-			OverheadTimeMs:    platformTime.Milliseconds(),
+		res := results.NewRecord(runMetadata, se.resultsFileName, startTime, stopTime)
+		if ssr.steadyState != nil {
+			res.TimeToSteadyStateMs = ssr.timeToSteadyState.Milliseconds()
+			res.SteadyState = ssr.steadyState
 		}
-		err = jsonEncoder.Encode(res)
+		setApplicationTimeline(res, nodeTimings)
+		res.Phases = unpausePhases
+		res.DataSizeBytes = se.preloadSize.Value()
+		res.ReplicationFactor = replicationFactor
+		res.Consistency = se.consistency.String()
+		res.Availability = availability
+		res.Latencies = latencies
+		res.Integrity = integrityResult
+		res.Connections = connections
+		err = resultSink.Write(ctx, res)
 		o.Expect(err).NotTo(o.HaveOccurred())
+
+		if integrityResult != nil {
+			o.Expect(integrityResult.MissingPartitions).To(o.BeEmpty(), "partitions are missing after unpausing")
+			o.Expect(integrityResult.CorruptPartitions).To(o.BeEmpty(), "partitions are corrupt after unpausing")
+		}
 	},
-		g.Entry("with pre-warmed ScyllaDBDatacenters", &scenarioEntry{
-			capacity:        1,
-			limit:           1,
-			resultsFileName: "prewarmed",
-		}),
-		g.Entry("with cold-started ScyllaDBDatacenters", &scenarioEntry{
-			capacity:        0,
-			limit:           0,
-			resultsFileName: "cold",
-		}),
+		scenarioEntries,
 	)
 
-	g.It("cold-starting with Scylla Operator", func(ctx g.SpecContext) {
-		const resultsFileName = "baseline"
-		resultsFilePath := path.Join(destDir, resultsFileName)
-		resultsFile, err := os.OpenFile(resultsFilePath, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0644)
+	type coldStartEntry struct {
+		replicationFactor int
+		consistency       gocql.Consistency
+		resultsFileName   string
+	}
+
+	var coldStartEntries []g.TableEntry
+	for _, rf := range replicationFactors {
+		for _, consistency := range consistencies {
+			description := "with Scylla Operator"
+			if rf != 0 {
+				description += fmt.Sprintf(" and replication factor %d", rf)
+			}
+			description += fmt.Sprintf(" at consistency level %v", consistency)
+
+			coldStartEntries = append(coldStartEntries, g.Entry(description, &coldStartEntry{
+				replicationFactor: rf,
+				consistency:       consistency,
+				resultsFileName:   "baseline" + sweep.ReplicationFactorResultsSuffix(rf) + sweep.ConsistencyResultsSuffix(consistency),
+			}))
+		}
+	}
+
+	g.DescribeTable("cold-starting", func(ctx g.SpecContext, ce *coldStartEntry) {
+		resultSink, err := results.NewSink(resultSinkOptions, ce.resultsFileName)
 		o.Expect(err).NotTo(o.HaveOccurred())
-		g.DeferCleanup(resultsFile.Close)
+		g.DeferCleanup(resultSink.Close)
 
 		c := f.Cluster(0)
 		ns, nsClient, ok := c.DefaultNamespaceIfAny()
@@ -505,9 +893,6 @@ var _ = g.Describe("measure time to readiness", func() {
 		sdc, err = utils.WaitForScyllaDBDatacenterState(ctx, nsClient.ScyllaClient().ScyllaV1alpha1().ScyllaDBDatacenters(ns.GetName()), sdc.GetName(), socontrollerhelpers.WaitForStateOptions{}, socontrollerhelpers.IsScyllaDBDatacenterRolledOut)
 		o.Expect(err).NotTo(o.HaveOccurred())
 
-		o.Expect(sdc.Spec.DNSDomains).To(o.HaveLen(1))
-		dnsDomain := sdc.Spec.DNSDomains[0]
-
 		connectionBundleDir, err := os.MkdirTemp(os.TempDir(), fmt.Sprintf("connection-bundle-%s-", ns.GetName()))
 		o.Expect(err).NotTo(o.HaveOccurred())
 		defer func() {
@@ -515,43 +900,25 @@ var _ = g.Describe("measure time to readiness", func() {
 			o.Expect(err).NotTo(o.HaveOccurred())
 		}()
 
-		var groupVersioner runtime.GroupVersioner = schema.GroupVersions([]schema.GroupVersion{cqlclientv1alpha1.GroupVersion})
-		decoder := scheme.Codecs.DecoderToVersion(scheme.Codecs.UniversalDeserializer(), groupVersioner)
-		encoder := scheme.Codecs.EncoderForVersion(scheme.DefaultYamlSerializer, groupVersioner)
-
 		framework.By("Injecting ingress controller address into the CQL connection bundle")
-		bundleSecret, err := nsClient.KubeClient().CoreV1().Secrets(sdc.Namespace).Get(ctx, sonaming.GetScyllaClusterLocalAdminCQLConnectionConfigsName(sdc.Name), metav1.GetOptions{})
-		o.Expect(err).NotTo(o.HaveOccurred())
-
-		cqlConnectionConfig := &cqlclientv1alpha1.CQLConnectionConfig{}
-		_, _, err = decoder.Decode(bundleSecret.Data[dnsDomain], nil, cqlConnectionConfig)
-		o.Expect(err).NotTo(o.HaveOccurred())
-
-		gossipDatacenterName := sonaming.GetScyllaDBDatacenterGossipDatacenterName(sdc)
-		cqlConnectionConfig.Datacenters[gossipDatacenterName].Server = ingressControllerAddress
-
-		cqlConnectionConfigData, err := runtime.Encode(encoder, cqlConnectionConfig)
-		o.Expect(err).NotTo(o.HaveOccurred())
-
-		cqlConnectionConfigFilePath := path.Join(connectionBundleDir, fmt.Sprintf("%s.yaml", dnsDomain))
-		framework.By("Saving CQL Connection Config in %s", cqlConnectionConfigFilePath)
-		err = os.WriteFile(cqlConnectionConfigFilePath, cqlConnectionConfigData, 0600)
+		cqlConnectionConfigFilePath, err := writeCQLConnectionConfig(ctx, c, sdc, connectionBundleDir)
 		o.Expect(err).NotTo(o.HaveOccurred())
 
 		framework.By("Connecting to cluster via Ingress")
-		cluster, err := scyllacloud.NewCloudCluster(cqlConnectionConfigFilePath)
+		cluster, err := newCloudCluster(cqlConnectionConfigFilePath)
 		o.Expect(err).NotTo(o.HaveOccurred())
-
-		// Increase default timeout, due to additional hop on the route to host.
-		cluster.Timeout = 10 * time.Second
-		cluster.Logger = nopLogger{}
-		cluster.Consistency = gocql.Quorum
+		cluster.Consistency = ce.consistency
 
 		session, err := gocqlx.WrapSession(cluster.CreateSession())
 		o.Expect(err).NotTo(o.HaveOccurred())
 
+		replicationFactor := ce.replicationFactor
+		if replicationFactor == 0 {
+			replicationFactor = len(getRackMembers(sdc))
+		}
+
 		// When overriding the sessions, "hosts" are only used by the data inserter to determine a replication factor.
-		hosts := slices.Repeat([]string{""}, nodeCount)
+		hosts := slices.Repeat([]string{""}, replicationFactor)
 
 		di, err := sotestutils.NewDataInserter(hosts, sotestutils.WithSession(&session))
 		o.Expect(err).NotTo(o.HaveOccurred())
@@ -562,9 +929,8 @@ var _ = g.Describe("measure time to readiness", func() {
 
 		framework.By("Getting VolumeAttachments for PVCs")
 		var backendVAs []*storagev1.VolumeAttachment
-		o.Expect(sdc.Spec.Racks).To(o.HaveLen(1))
-		for i := range nodeCount {
-			podName := sonaming.MemberServiceName(sdc.Spec.Racks[0], sdc, i)
+		for _, m := range getRackMembers(sdc) {
+			podName := sonaming.MemberServiceName(m.rack, sdc, int(m.idx))
 			pod, err := nsClient.KubeClient().CoreV1().Pods(ns.GetName()).Get(ctx, podName, metav1.GetOptions{})
 			o.Expect(err).NotTo(o.HaveOccurred())
 			o.Expect(pod.Spec.NodeName).NotTo(o.BeEmpty())
@@ -620,9 +986,8 @@ var _ = g.Describe("measure time to readiness", func() {
 
 		framework.By("Verifying PVs' presence")
 
-		o.Expect(sdc.Spec.Racks).To(o.HaveLen(1))
-		for i := range nodeCount {
-			podName := sonaming.MemberServiceName(sdc.Spec.Racks[0], sdc, i)
+		for _, m := range getRackMembers(sdc) {
+			podName := sonaming.MemberServiceName(m.rack, sdc, int(m.idx))
 
 			backendPVCName := sonaming.PVCNameForPod(podName)
 			backendPVC, err := nsClient.KubeClient().CoreV1().PersistentVolumeClaims(ns.GetName()).Get(ctx, backendPVCName, metav1.GetOptions{})
@@ -647,32 +1012,13 @@ var _ = g.Describe("measure time to readiness", func() {
 		o.Expect(err).NotTo(o.HaveOccurred())
 
 		framework.By("Injecting ingress controller address into the CQL connection bundle")
-		bundleSecret, err = nsClient.KubeClient().CoreV1().Secrets(sdc.Namespace).Get(ctx, sonaming.GetScyllaClusterLocalAdminCQLConnectionConfigsName(sdc.Name), metav1.GetOptions{})
-		o.Expect(err).NotTo(o.HaveOccurred())
-
-		cqlConnectionConfig = &cqlclientv1alpha1.CQLConnectionConfig{}
-		_, _, err = decoder.Decode(bundleSecret.Data[dnsDomain], nil, cqlConnectionConfig)
-		o.Expect(err).NotTo(o.HaveOccurred())
-
-		gossipDatacenterName = sonaming.GetScyllaDBDatacenterGossipDatacenterName(sdc)
-		cqlConnectionConfig.Datacenters[gossipDatacenterName].Server = ingressControllerAddress
-
-		cqlConnectionConfigData, err = runtime.Encode(encoder, cqlConnectionConfig)
-		o.Expect(err).NotTo(o.HaveOccurred())
-
-		cqlConnectionConfigFilePath = path.Join(connectionBundleDir, fmt.Sprintf("%s.yaml", dnsDomain))
-		framework.By("Saving CQL Connection Config in %s", cqlConnectionConfigFilePath)
-		err = os.WriteFile(cqlConnectionConfigFilePath, cqlConnectionConfigData, 0600)
+		cqlConnectionConfigFilePath, err = writeCQLConnectionConfig(ctx, c, sdc, connectionBundleDir)
 		o.Expect(err).NotTo(o.HaveOccurred())
 
 		framework.By("Connecting to cluster via Ingress")
-		cluster, err = scyllacloud.NewCloudCluster(cqlConnectionConfigFilePath)
+		cluster, err = newCloudCluster(cqlConnectionConfigFilePath)
 		o.Expect(err).NotTo(o.HaveOccurred())
-
-		// Increase default timeout, due to additional hop on the route to host.
-		cluster.Timeout = 10 * time.Second
-		cluster.Logger = nopLogger{}
-		cluster.Consistency = gocql.Quorum
+		cluster.Consistency = ce.consistency
 
 		newSession, err := gocqlx.WrapSession(cluster.CreateSession())
 		o.Expect(err).NotTo(o.HaveOccurred())
@@ -682,22 +1028,18 @@ var _ = g.Describe("measure time to readiness", func() {
 		scyllaclusterverification.VerifyCQLData(ctx, di)
 		newSession.Close()
 
-		scyllaStartToServeTime := getScyllaContainerTimeToServeCQL(ctx, nsClient.KubeClient().CoreV1().Pods(ns.GetName()), sdc)
-		o.Expect(scyllaStartToServeTime).NotTo(o.BeZero())
-
-		totalTime := stopTime.Sub(startTime)
-		platformTime := totalTime - scyllaStartToServeTime
-		framework.Infof("Total time: %v.\nPlatform time: %v.\nApplication time: %v.\n", totalTime, platformTime, scyllaStartToServeTime)
+		nodeTimings := getScyllaNodeTimings(ctx, nsClient.KubeClient().CoreV1().Pods(ns.GetName()), sdc)
+		o.Expect(nodeTimings).NotTo(o.BeEmpty())
 
-		jsonEncoder := json.NewEncoder(resultsFile)
-		res := result{
-			ElapsedTimeMs:     totalTime.Milliseconds(),
-			ApplicationTimeMs: scyllaStartToServeTime.Milliseconds(),
-			OverheadTimeMs:    platformTime.Milliseconds(),
-		}
-		err = jsonEncoder.Encode(res)
+		res := results.NewRecord(runMetadata, ce.resultsFileName, startTime, stopTime)
+		res.ReplicationFactor = replicationFactor
+		res.Consistency = ce.consistency.String()
+		setApplicationTimeline(res, nodeTimings)
+		err = resultSink.Write(ctx, res)
 		o.Expect(err).NotTo(o.HaveOccurred())
-	})
+	},
+		coldStartEntries,
+	)
 })
 
 type nopLogger struct{}
@@ -723,11 +1065,11 @@ func getScyllaDBDatacenterSpec(backendImmediateStorageClassName string) scyllav1
 		ClusterName:    "basic",
 		DatacenterName: ptr.To("us-east-1"),
 		ScyllaDB: scyllav1alpha1.ScyllaDB{
-			Image:               "docker.io/scylladb/scylla:6.2.3@sha256:a9d904089abe9a4f8b5b893ebb5b5bf8b5a1bd0dc6658921cf05f89d3712289c",
+			Image:               scyllaDBImage,
 			EnableDeveloperMode: ptr.To(false),
 		},
 		ScyllaDBManagerAgent: &scyllav1alpha1.ScyllaDBManagerAgent{
-			Image: ptr.To("docker.io/scylladb/scylla-manager-agent:3.4.1@sha256:392ce6d3971ae077cc58b3cd2c7da1e9572f9f76223dfd5e11445c32e7ab0396"),
+			Image: ptr.To(scyllaDBManagerAgentImage),
 		},
 		ExposeOptions: &scyllav1alpha1.ExposeOptions{
 			NodeService: &scyllav1alpha1.NodeServiceTemplate{
@@ -756,7 +1098,7 @@ func getScyllaDBDatacenterSpec(backendImmediateStorageClassName string) scyllav1
 					},
 				},
 				Storage: &scyllav1alpha1.StorageOptions{
-					Capacity:         "10Gi",
+					Capacity:         storageCapacity.String(),
 					StorageClassName: ptr.To(backendImmediateStorageClassName),
 				},
 			},
@@ -773,57 +1115,148 @@ func getScyllaDBDatacenterSpec(backendImmediateStorageClassName string) scyllav1
 				},
 			},
 		},
-		Racks: []scyllav1alpha1.RackSpec{
+		MinReadySeconds: ptr.To[int32](0),
+		ReadinessGates: []corev1.PodReadinessGate{
 			{
-				Name: "us-east-1a",
-				RackTemplate: scyllav1alpha1.RackTemplate{
-					Placement: &scyllav1alpha1.Placement{
-						NodeAffinity: &corev1.NodeAffinity{
-							RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
-								NodeSelectorTerms: []corev1.NodeSelectorTerm{
+				ConditionType: psonaming.IngressControllerScyllaDBMemberPodConditionType,
+			},
+		},
+	}
+
+	for i := range racks {
+		sdcSpec.Racks = append(sdcSpec.Racks, getRackSpec(i))
+	}
+
+	return sdcSpec
+}
+
+// getRackSpec returns the spec of the i-th rack, placed in one of the topology zones in a round-robin fashion.
+func getRackSpec(i int) scyllav1alpha1.RackSpec {
+	rackSpec := scyllav1alpha1.RackSpec{
+		Name: fmt.Sprintf("us-east-1%c", 'a'+i),
+		RackTemplate: scyllav1alpha1.RackTemplate{
+			Placement: &scyllav1alpha1.Placement{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{
+							{
+								MatchExpressions: []corev1.NodeSelectorRequirement{
 									{
-										MatchExpressions: []corev1.NodeSelectorRequirement{
-											{
-												Key:      "scylla.scylladb.com/node-type",
-												Operator: corev1.NodeSelectorOpIn,
-												Values:   []string{"scylla"},
-											},
-										},
+										Key:      "scylla.scylladb.com/node-type",
+										Operator: corev1.NodeSelectorOpIn,
+										Values:   []string{"scylla"},
 									},
 								},
 							},
 						},
-						Tolerations: []corev1.Toleration{
-							{
-								Key:      "scylla-operator.scylladb.com/dedicated",
-								Operator: corev1.TolerationOpEqual,
-								Value:    "scyllaclusters",
-								Effect:   corev1.TaintEffectNoSchedule,
-							},
-						},
 					},
 				},
-			},
-		},
-		MinReadySeconds: ptr.To[int32](0),
-		ReadinessGates: []corev1.PodReadinessGate{
-			{
-				ConditionType: psonaming.IngressControllerScyllaDBMemberPodConditionType,
+				Tolerations: []corev1.Toleration{
+					{
+						Key:      "scylla-operator.scylladb.com/dedicated",
+						Operator: corev1.TolerationOpEqual,
+						Value:    "scyllaclusters",
+						Effect:   corev1.TaintEffectNoSchedule,
+					},
+				},
 			},
 		},
 	}
 
-	if len(topologyZoneLabelValue) > 0 {
-		sdcSpec.Racks[0].Placement.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions = append(
-			sdcSpec.Racks[0].Placement.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions,
+	if len(topologyZones) > 0 {
+		rackSpec.Placement.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions = append(
+			rackSpec.Placement.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions,
 			corev1.NodeSelectorRequirement{
 				Key:      corev1.LabelTopologyZone,
 				Operator: corev1.NodeSelectorOpIn,
-				Values:   []string{topologyZoneLabelValue},
+				Values:   []string{topologyZones[i%len(topologyZones)]},
 			})
 	}
 
-	return sdcSpec
+	return rackSpec
+}
+
+type rackMember struct {
+	rack scyllav1alpha1.RackSpec
+	idx  int32
+}
+
+func getRackMembers(sdc *scyllav1alpha1.ScyllaDBDatacenter) []rackMember {
+	members, err := listRackMembers(sdc)
+	o.Expect(err).NotTo(o.HaveOccurred())
+
+	return members
+}
+
+func listRackMembers(sdc *scyllav1alpha1.ScyllaDBDatacenter) ([]rackMember, error) {
+	var members []rackMember
+	for _, rack := range sdc.Spec.Racks {
+		rackNodes, err := socontrollerhelpers.GetRackNodeCount(sdc, rack.Name)
+		if err != nil {
+			return nil, fmt.Errorf("can't get node count of rack %q: %w", rack.Name, err)
+		}
+
+		for i := range *rackNodes {
+			members = append(members, rackMember{
+				rack: rack,
+				idx:  i,
+			})
+		}
+	}
+
+	return members, nil
+}
+
+func getScyllaDBDatacenterPool(name string, backendImmediateStorageClassName string, capacity, limit int32) *pausingv1alpha1.ScyllaDBDatacenterPool {
+	sdcp := &pausingv1alpha1.ScyllaDBDatacenterPool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: pausingv1alpha1.ScyllaDBDatacenterPoolSpec{
+			Template: pausingv1alpha1.ScyllaDBDatacenterTemplate{
+				Spec: getScyllaDBDatacenterSpec(backendImmediateStorageClassName),
+			},
+			Capacity:              capacity,
+			Limit:                 limit,
+			ProxyStorageClassName: proxyStorageClassName,
+		},
+	}
+
+	sdcp.Spec.Template.Spec.CertificateOptions = &scyllav1alpha1.CertificateOptions{
+		ServingCA: &scyllav1alpha1.TLSCertificateAuthority{
+			Type: scyllav1alpha1.TLSCertificateAuthorityTypeUserManaged,
+			UserManagedOptions: &scyllav1alpha1.UserManagedTLSCertificateAuthorityOptions{
+				SecretName: "",
+			},
+		},
+		ClientCA: &scyllav1alpha1.TLSCertificateAuthority{
+			Type: scyllav1alpha1.TLSCertificateAuthorityTypeUserManaged,
+			UserManagedOptions: &scyllav1alpha1.UserManagedTLSCertificateAuthorityOptions{
+				SecretName: "",
+			},
+		},
+	}
+
+	return sdcp
+}
+
+func getPausableScyllaDBDatacenter(name string, scyllaDBDatacenterPoolName string) *pausingv1alpha1.PausableScyllaDBDatacenter {
+	return &pausingv1alpha1.PausableScyllaDBDatacenter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: pausingv1alpha1.PausableScyllaDBDatacenterSpec{
+			ScyllaDBDatacenterPoolName: scyllaDBDatacenterPoolName,
+			Paused:                     ptr.To(false),
+			ExposeOptions: &pausingv1alpha1.ExposeOptions{
+				CQL: &scyllav1alpha1.CQLExposeOptions{
+					Ingress: &scyllav1alpha1.CQLExposeIngressOptions{
+						IngressClassName: ingressClassName,
+					},
+				},
+			},
+		},
+	}
 }
 
 func getAttachmentName(volumeHandle string, driverName string, nodeName string) string {
@@ -831,42 +1264,63 @@ func getAttachmentName(volumeHandle string, driverName string, nodeName string)
 	return fmt.Sprintf("csi-%x", result)
 }
 
-func getScyllaContainerTimeToServeCQL(ctx context.Context, podClient corev1client.PodInterface, sdc *scyllav1alpha1.ScyllaDBDatacenter) time.Duration {
-	stsName := sonaming.StatefulSetNameForRack(sdc.Spec.Racks[0], sdc)
-	rackNodes, err := socontrollerhelpers.GetRackNodeCount(sdc, sdc.Spec.Racks[0].Name)
-	o.Expect(err).NotTo(o.HaveOccurred())
+func getScyllaNodeTimings(ctx context.Context, podClient corev1client.PodInterface, sdc *scyllav1alpha1.ScyllaDBDatacenter) []results.NodeTiming {
+	var nodeTimings []results.NodeTiming
+	for _, rack := range sdc.Spec.Racks {
+		stsName := sonaming.StatefulSetNameForRack(rack, sdc)
+		rackNodes, err := socontrollerhelpers.GetRackNodeCount(sdc, rack.Name)
+		o.Expect(err).NotTo(o.HaveOccurred())
 
-	scyllaTimeToServeTimes := make([]time.Duration, nodeCount)
-	for i := int32(0); i < *rackNodes; i++ {
-		podName := fmt.Sprintf("%s-%d", stsName, i)
+		for i := int32(0); i < *rackNodes; i++ {
+			podName := fmt.Sprintf("%s-%d", stsName, i)
 
-		var scyllaProcessStartTime, scyllaServingStartTime time.Time
+			logs, err := podClient.GetLogs(podName, &corev1.PodLogOptions{
+				Container:  sonaming.ScyllaContainerName,
+				Timestamps: true,
+			}).Stream(ctx)
+			o.Expect(err).NotTo(o.HaveOccurred())
 
-		var logs io.ReadCloser
-		logs, err = podClient.GetLogs(podName, &corev1.PodLogOptions{
-			Container:  sonaming.ScyllaContainerName,
-			Timestamps: true,
-		}).Stream(ctx)
-		o.Expect(err).NotTo(o.HaveOccurred())
+			boot, err := bootlog.Parse(logs)
+			o.Expect(err).NotTo(o.HaveOccurred())
+			o.Expect(logs.Close()).To(o.Succeed())
 
-		scanner := bufio.NewScanner(logs)
-		for scanner.Scan() {
-			line := scanner.Text()
-			if matches := scyllaProcessStartRegex.FindStringSubmatch(line); matches != nil && len(matches) > 1 {
-				scyllaProcessStartTime, err = time.Parse(time.RFC3339Nano, matches[1])
-				o.Expect(err).NotTo(o.HaveOccurred())
-			} else if matches = scyllaServingStartRegex.FindStringSubmatch(line); matches != nil && len(matches) > 1 {
-				scyllaServingStartTime, err = time.Parse(time.RFC3339Nano, matches[1])
-				o.Expect(err).NotTo(o.HaveOccurred())
-			}
+			scyllaProcessStartTime, ok := boot.Time(bootlog.ProcessStarted)
+			o.Expect(ok).To(o.BeTrue())
+			scyllaServingStartTime, ok := boot.Time(bootlog.Serving)
+			o.Expect(ok).To(o.BeTrue())
+
+			nodeTiming := results.NewNodeTiming(podName, rack.Name, scyllaProcessStartTime, scyllaServingStartTime)
+			nodeTiming.BootPhases, err = boot.Phases()
+			o.Expect(err).NotTo(o.HaveOccurred())
+			nodeTimings = append(nodeTimings, nodeTiming)
 		}
+	}
+
+	return nodeTimings
+}
+
+func setApplicationTimeline(res *results.Record, nodeTimings []results.NodeTiming) {
+	err := res.SetApplicationTimeline(nodeTimings)
+	o.Expect(err).NotTo(o.HaveOccurred())
+
+	for _, node := range res.Nodes {
+		framework.Infof("Node %q in rack %q started its process at %v and was serving after %dms (critical path: %t).", node.Pod, node.Rack, node.ProcessStartTime, node.TimeToServeMs, node.CriticalPath)
+	}
+
+	for _, rt := range res.RackTimings() {
+		framework.Infof("Rack %q with %d node(s) took %dms of application time and was serving after %dms (critical path: %t).", rt.Rack, rt.Nodes, rt.ApplicationTimeMs, rt.ServingOffsetMs, rt.CriticalPath)
+	}
+
+	framework.Infof("Total time: %dms.\nPlatform time: %dms (%dms before and %dms after the application on the critical path).\nApplication time: %dms.\n", res.ElapsedTimeMs, res.OverheadTimeMs, res.OverheadBeforeApplicationMs, res.OverheadAfterApplicationMs, res.ApplicationTimeMs)
+}
 
-		o.Expect(scyllaProcessStartTime.IsZero()).To(o.BeFalse())
-		o.Expect(scyllaServingStartTime.IsZero()).To(o.BeFalse())
-		scyllaTimeToServeTimes[i] = scyllaServingStartTime.Sub(scyllaProcessStartTime)
+func getAvailability(startTime time.Time, report *probe.Report) *results.Availability {
+	errorHistogram := map[string]int{}
+	for errorClass, n := range report.ErrorHistogram() {
+		errorHistogram[string(errorClass)] = n
 	}
 
-	return slices.Max(scyllaTimeToServeTimes)
+	return results.NewAvailability(startTime, len(report.Attempts), report.FirstSuccessTime, report.FirstWriteTime, errorHistogram)
 }
 
 func isScyllaDBDatacenterAvailable(sdc *scyllav1alpha1.ScyllaDBDatacenter) (bool, error) {
@@ -0,0 +1,243 @@
+package pausable_scylladb_operator_benchmarks_test
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	g "github.com/onsi/ginkgo/v2"
+	o "github.com/onsi/gomega"
+	"github.com/pausing-clusters-thesis/benchmarks/framework"
+	"github.com/pausing-clusters-thesis/benchmarks/results"
+	"github.com/pausing-clusters-thesis/benchmarks/timeline"
+	"github.com/pausing-clusters-thesis/benchmarks/utils"
+	pausingv1alpha1 "github.com/pausing-clusters-thesis/pausable-scylladb-operator/pkg/api/pausing/v1alpha1"
+	psocontrollerhelpers "github.com/pausing-clusters-thesis/pausable-scylladb-operator/pkg/controllerhelpers"
+	psonaming "github.com/pausing-clusters-thesis/pausable-scylladb-operator/pkg/naming"
+	socontrollerhelpers "github.com/scylladb/scylla-operator/pkg/controllerhelpers"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	replenishmentTimeout = 15 * time.Minute
+
+	poolDrainedMilestone     = "pool-drained"
+	poolReplenishedMilestone = "pool-replenished"
+)
+
+func instanceCreatedMilestone(idx int) string {
+	return fmt.Sprintf("instance-%d-created", idx)
+}
+
+func instanceReadyMilestone(idx int) string {
+	return fmt.Sprintf("instance-%d-ready", idx)
+}
+
+// poolReplenishmentObserver follows a ScyllaDBDatacenterPool replacing the instances taken by claims.
+// Replacement instances are numbered in the order they were created and, separately, in the order they became ready,
+// so that the n-th ready instance can be compared across runs.
+type poolReplenishmentObserver struct {
+	cluster   *framework.Cluster
+	namespace string
+	sdcp      *pausingv1alpha1.ScyllaDBDatacenterPool
+
+	initialInstances  sets.Set[string]
+	drainedTime       time.Time
+	replenishedTime   time.Time
+	instanceCreated   map[string]time.Time
+	instanceReadyTime map[string]time.Time
+}
+
+func newPoolReplenishmentObserver(ctx context.Context, cluster *framework.Cluster, namespace string, sdcp *pausingv1alpha1.ScyllaDBDatacenterPool) (*poolReplenishmentObserver, error) {
+	sdcList, err := cluster.ScyllaAdminClient().ScyllaV1alpha1().ScyllaDBDatacenters(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: psonaming.ScyllaDBDatacenterPoolSelector(sdcp).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't list ScyllaDBDatacenters of pool %q: %w", sdcp.Name, err)
+	}
+
+	initialInstances := sets.New[string]()
+	for _, sdc := range sdcList.Items {
+		initialInstances.Insert(sdc.Name)
+	}
+
+	return &poolReplenishmentObserver{
+		cluster:           cluster,
+		namespace:         namespace,
+		sdcp:              sdcp,
+		initialInstances:  initialInstances,
+		instanceCreated:   map[string]time.Time{},
+		instanceReadyTime: map[string]time.Time{},
+	}, nil
+}
+
+// observe records the current state of the pool and reports whether it got back to capacity
+// with the expected number of replacement instances ready.
+func (pro *poolReplenishmentObserver) observe(ctx context.Context, expectedReplacements int) (bool, error) {
+	now := time.Now()
+
+	sdcList, err := pro.cluster.ScyllaAdminClient().ScyllaV1alpha1().ScyllaDBDatacenters(pro.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: psonaming.ScyllaDBDatacenterPoolSelector(pro.sdcp).String(),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	for _, sdc := range sdcList.Items {
+		if pro.initialInstances.Has(sdc.Name) {
+			continue
+		}
+
+		if _, ok := pro.instanceCreated[sdc.Name]; !ok {
+			pro.instanceCreated[sdc.Name] = sdc.CreationTimestamp.Time
+		}
+
+		if _, ok := pro.instanceReadyTime[sdc.Name]; !ok && psocontrollerhelpers.IsScyllaDBDatacenterPrewarmed(&sdc) {
+			pro.instanceReadyTime[sdc.Name] = now
+		}
+	}
+
+	sdcp, err := pro.cluster.PausingAdminClient().PausingV1alpha1().ScyllaDBDatacenterPools(pro.namespace).Get(ctx, pro.sdcp.Name, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+
+	readyInstances := ptr.Deref(sdcp.Status.ReadyInstances, 0)
+	if readyInstances < sdcp.Spec.Capacity && pro.drainedTime.IsZero() {
+		pro.drainedTime = now
+	}
+
+	if pro.drainedTime.IsZero() || readyInstances < sdcp.Spec.Capacity || len(pro.instanceReadyTime) < expectedReplacements {
+		return false, nil
+	}
+
+	pro.replenishedTime = now
+	return true, nil
+}
+
+func (pro *poolReplenishmentObserver) phases(startTime time.Time) []results.Phase {
+	type milestone struct {
+		name string
+		time time.Time
+	}
+
+	milestones := []milestone{
+		{name: poolDrainedMilestone, time: pro.drainedTime},
+	}
+
+	addOrdered := func(times map[string]time.Time, milestoneName func(int) string) {
+		instances := make([]string, 0, len(times))
+		for name := range times {
+			instances = append(instances, name)
+		}
+		slices.SortFunc(instances, func(a, b string) int {
+			return times[a].Compare(times[b])
+		})
+
+		for i, name := range instances {
+			milestones = append(milestones, milestone{name: milestoneName(i), time: times[name]})
+		}
+	}
+	addOrdered(pro.instanceCreated, instanceCreatedMilestone)
+	addOrdered(pro.instanceReadyTime, instanceReadyMilestone)
+
+	milestones = append(milestones, milestone{name: poolReplenishedMilestone, time: pro.replenishedTime})
+	slices.SortStableFunc(milestones, func(a, b milestone) int {
+		return a.time.Compare(b.time)
+	})
+
+	stopwatch := timeline.NewStopwatch(startTime)
+	for _, m := range milestones {
+		stopwatch.LapAt(m.name, m.time)
+	}
+
+	return stopwatch.Phases()
+}
+
+var _ = g.Describe("measure pool replenishment", func() {
+	f := framework.NewFramework("benchmark")
+
+	type replenishmentEntry struct {
+		claims          int32
+		resultsFileName string
+	}
+
+	g.DescribeTable("when claims consume pre-warmed ScyllaDBDatacenters", func(ctx g.SpecContext, re *replenishmentEntry) {
+		resultSink, err := results.NewSink(resultSinkOptions, re.resultsFileName)
+		o.Expect(err).NotTo(o.HaveOccurred())
+		g.DeferCleanup(resultSink.Close)
+
+		c := f.Cluster(0)
+		ns, _, ok := c.DefaultNamespaceIfAny()
+		o.Expect(ok).To(o.BeTrue())
+
+		backendImmediateStorageClass, err := utils.GetImmediateStorageClassForCSIDriver(backendCSIDriverName)
+		o.Expect(err).NotTo(o.HaveOccurred())
+
+		framework.By("Creating immediate StorageClass for backend CSI driver")
+		backendImmediateStorageClass, err = c.KubeAdminClient().StorageV1().StorageClasses().Create(ctx, backendImmediateStorageClass, metav1.CreateOptions{})
+		o.Expect(err).NotTo(o.HaveOccurred())
+
+		g.DeferCleanup(func(ctx g.SpecContext, backendImmediateStorageClass *storagev1.StorageClass) {
+			framework.By("Deleting immediate StorageClass")
+			err := c.KubeAdminClient().StorageV1().StorageClasses().Delete(ctx, backendImmediateStorageClass.GetName(), metav1.DeleteOptions{})
+			o.Expect(err).NotTo(o.HaveOccurred())
+		}, backendImmediateStorageClass)
+
+		framework.By("Creating a ScyllaDBDatacenterPool with capacity for all claims")
+		// The limit leaves room for the replacements of all claimed instances.
+		sdcp := getScyllaDBDatacenterPool("basic", backendImmediateStorageClass.GetName(), re.claims, 2*re.claims)
+		sdcp, err = c.PausingAdminClient().PausingV1alpha1().ScyllaDBDatacenterPools(ns.GetName()).Create(ctx, sdcp, metav1.CreateOptions{})
+		o.Expect(err).NotTo(o.HaveOccurred())
+
+		framework.By("Waiting for ScyllaDBDatacenterPool to roll out")
+		sdcp, err = psocontrollerhelpers.WaitForScyllaDBDatacenterPoolState(ctx, c.PausingAdminClient().PausingV1alpha1().ScyllaDBDatacenterPools(ns.GetName()), sdcp.GetName(), socontrollerhelpers.WaitForStateOptions{}, psocontrollerhelpers.IsScyllaDBDatacenterPoolRolledOut, isScyllaDBDatacenterPoolAtCapacity)
+		o.Expect(err).NotTo(o.HaveOccurred())
+
+		observer, err := newPoolReplenishmentObserver(ctx, c, ns.GetName(), sdcp)
+		o.Expect(err).NotTo(o.HaveOccurred())
+		o.Expect(observer.initialInstances.Len()).To(o.BeEquivalentTo(re.claims))
+
+		framework.By("Creating %d PausableScyllaDBDatacenter(s) claiming pre-warmed instances", re.claims)
+		startTime := time.Now()
+		for i := range re.claims {
+			psdc := getPausableScyllaDBDatacenter(fmt.Sprintf("tenant-%d", i), sdcp.GetName())
+			_, err = c.PausingAdminClient().PausingV1alpha1().PausableScyllaDBDatacenters(ns.GetName()).Create(ctx, psdc, metav1.CreateOptions{})
+			o.Expect(err).NotTo(o.HaveOccurred())
+		}
+
+		framework.By("Waiting for ScyllaDBDatacenterPool to be replenished")
+		err = wait.PollUntilContextTimeout(ctx, timeline.DefaultPollInterval, replenishmentTimeout, true, func(ctx context.Context) (bool, error) {
+			return observer.observe(ctx, int(re.claims))
+		})
+		o.Expect(err).NotTo(o.HaveOccurred())
+
+		for name, createdTime := range observer.instanceCreated {
+			framework.Infof("Replacement instance %q was created after %v and ready after %v.", name, createdTime.Sub(startTime), observer.instanceReadyTime[name].Sub(startTime))
+		}
+
+		res := results.NewRecord(runMetadata, re.resultsFileName, startTime, observer.replenishedTime)
+		res.Phases = observer.phases(startTime)
+		framework.Infof("Pool was replenished after %dms.", res.ElapsedTimeMs)
+		err = resultSink.Write(ctx, res)
+		o.Expect(err).NotTo(o.HaveOccurred())
+	},
+		g.Entry("with a single claim", &replenishmentEntry{
+			claims:          1,
+			resultsFileName: "replenishment",
+		}),
+		g.Entry("with concurrent claims draining the pool", &replenishmentEntry{
+			claims:          3,
+			resultsFileName: "replenishment-concurrent",
+		}),
+	)
+})
+
+func isScyllaDBDatacenterPoolAtCapacity(sdcp *pausingv1alpha1.ScyllaDBDatacenterPool) (bool, error) {
+	return ptr.Deref(sdcp.Status.ReadyInstances, 0) >= sdcp.Spec.Capacity, nil
+}
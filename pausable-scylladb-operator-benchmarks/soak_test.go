@@ -0,0 +1,320 @@
+package pausable_scylladb_operator_benchmarks_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	g "github.com/onsi/ginkgo/v2"
+	o "github.com/onsi/gomega"
+	"github.com/pausing-clusters-thesis/benchmarks/framework"
+	"github.com/pausing-clusters-thesis/benchmarks/integrity"
+	"github.com/pausing-clusters-thesis/benchmarks/results"
+	"github.com/pausing-clusters-thesis/benchmarks/stats"
+	"github.com/pausing-clusters-thesis/benchmarks/timeline"
+	"github.com/pausing-clusters-thesis/benchmarks/utils"
+	psocontrollerhelpers "github.com/pausing-clusters-thesis/pausable-scylladb-operator/pkg/controllerhelpers"
+	scyllav1alpha1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1alpha1"
+	socontrollerhelpers "github.com/scylladb/scylla-operator/pkg/controllerhelpers"
+	soframework "github.com/scylladb/scylla-operator/test/e2e/framework"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	leakCheckTimeout = 5 * time.Minute
+
+	cycleLabel = "cycle"
+)
+
+const (
+	persistentVolumesKind      = "PersistentVolumes"
+	persistentVolumeClaimsKind = "PersistentVolumeClaims"
+	volumeAttachmentsKind      = "VolumeAttachments"
+	scyllaDBDatacentersKind    = "ScyllaDBDatacenters"
+	secretsKind                = "Secrets"
+)
+
+// resourceCounts are the numbers of objects of each kind that belong to a namespace.
+type resourceCounts map[string]int
+
+func (rc resourceCounts) String() string {
+	return fmt.Sprintf("%s: %d, %s: %d, %s: %d, %s: %d, %s: %d", persistentVolumesKind, rc[persistentVolumesKind], persistentVolumeClaimsKind, rc[persistentVolumeClaimsKind], volumeAttachmentsKind, rc[volumeAttachmentsKind], scyllaDBDatacentersKind, rc[scyllaDBDatacentersKind], secretsKind, rc[secretsKind])
+}
+
+// namespaceResourceCounter counts the objects belonging to a namespace, including the cluster-scoped ones.
+// PersistentVolumes are attributed to the namespace by their claim references and VolumeAttachments by their
+// PersistentVolumes. Names of all PersistentVolumes seen so far are remembered, so that attachments of volumes
+// that are already gone are still counted.
+type namespaceResourceCounter struct {
+	cluster   *framework.Cluster
+	namespace string
+
+	knownPersistentVolumes sets.Set[string]
+}
+
+func newNamespaceResourceCounter(cluster *framework.Cluster, namespace string) *namespaceResourceCounter {
+	return &namespaceResourceCounter{
+		cluster:                cluster,
+		namespace:              namespace,
+		knownPersistentVolumes: sets.New[string](),
+	}
+}
+
+func (nrc *namespaceResourceCounter) count(ctx context.Context) (resourceCounts, error) {
+	counts := resourceCounts{}
+
+	pvList, err := nrc.cluster.KubeAdminClient().CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("can't list PersistentVolumes: %w", err)
+	}
+	for _, pv := range pvList.Items {
+		if pv.Spec.ClaimRef == nil || pv.Spec.ClaimRef.Namespace != nrc.namespace {
+			continue
+		}
+
+		nrc.knownPersistentVolumes.Insert(pv.Name)
+		counts[persistentVolumesKind]++
+	}
+
+	vaList, err := nrc.cluster.KubeAdminClient().StorageV1().VolumeAttachments().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("can't list VolumeAttachments: %w", err)
+	}
+	for _, va := range vaList.Items {
+		if va.Spec.Source.PersistentVolumeName != nil && nrc.knownPersistentVolumes.Has(*va.Spec.Source.PersistentVolumeName) {
+			counts[volumeAttachmentsKind]++
+		}
+	}
+
+	pvcList, err := nrc.cluster.KubeAdminClient().CoreV1().PersistentVolumeClaims(nrc.namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("can't list PersistentVolumeClaims: %w", err)
+	}
+	counts[persistentVolumeClaimsKind] = len(pvcList.Items)
+
+	secretList, err := nrc.cluster.KubeAdminClient().CoreV1().Secrets(nrc.namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("can't list Secrets: %w", err)
+	}
+	counts[secretsKind] = len(secretList.Items)
+
+	sdcList, err := nrc.cluster.ScyllaAdminClient().ScyllaV1alpha1().ScyllaDBDatacenters(nrc.namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("can't list ScyllaDBDatacenters: %w", err)
+	}
+	counts[scyllaDBDatacentersKind] = len(sdcList.Items)
+
+	return counts, nil
+}
+
+// waitForCounts waits until the objects in the namespace get back to the expected counts and returns the last counts observed.
+func (nrc *namespaceResourceCounter) waitForCounts(ctx context.Context, expected resourceCounts) (resourceCounts, error) {
+	var counts resourceCounts
+	err := wait.PollUntilContextTimeout(ctx, time.Second, leakCheckTimeout, true, func(ctx context.Context) (bool, error) {
+		var err error
+		counts, err = nrc.count(ctx)
+		if err != nil {
+			return false, err
+		}
+
+		for kind, n := range expected {
+			if counts[kind] != n {
+				return false, nil
+			}
+		}
+
+		for kind, n := range counts {
+			if expected[kind] != n {
+				return false, nil
+			}
+		}
+
+		return true, nil
+	})
+
+	return counts, err
+}
+
+var _ = g.Describe("measure repeated pausing", func() {
+	f := framework.NewFramework("benchmark")
+
+	g.It("when cycling a PausableScyllaDBDatacenter through pause and unpause", func(ctx g.SpecContext) {
+		if soakCycles == 0 {
+			g.Skip("soak-cycles is zero")
+		}
+
+		resultsName := fmt.Sprintf("soak-capacity-%d", soakPoolCapacity)
+		unpauseResultSink, err := results.NewSink(resultSinkOptions, resultsName)
+		o.Expect(err).NotTo(o.HaveOccurred())
+		g.DeferCleanup(unpauseResultSink.Close)
+
+		pauseResultSink, err := results.NewSink(resultSinkOptions, resultsName+"-pause")
+		o.Expect(err).NotTo(o.HaveOccurred())
+		g.DeferCleanup(pauseResultSink.Close)
+
+		c := f.Cluster(0)
+		ns, nsClient, ok := c.DefaultNamespaceIfAny()
+		o.Expect(ok).To(o.BeTrue())
+
+		backendImmediateStorageClass, err := utils.GetImmediateStorageClassForCSIDriver(backendCSIDriverName)
+		o.Expect(err).NotTo(o.HaveOccurred())
+
+		framework.By("Creating immediate StorageClass for backend CSI driver")
+		backendImmediateStorageClass, err = c.KubeAdminClient().StorageV1().StorageClasses().Create(ctx, backendImmediateStorageClass, metav1.CreateOptions{})
+		o.Expect(err).NotTo(o.HaveOccurred())
+
+		g.DeferCleanup(func(ctx g.SpecContext, backendImmediateStorageClass *storagev1.StorageClass) {
+			framework.By("Deleting immediate StorageClass")
+			err := c.KubeAdminClient().StorageV1().StorageClasses().Delete(ctx, backendImmediateStorageClass.GetName(), metav1.DeleteOptions{})
+			o.Expect(err).NotTo(o.HaveOccurred())
+		}, backendImmediateStorageClass)
+
+		framework.By("Creating a ScyllaDBDatacenterPool")
+		sdcp := getScyllaDBDatacenterPool("basic", backendImmediateStorageClass.GetName(), int32(soakPoolCapacity), int32(soakPoolCapacity))
+		sdcp, err = c.PausingAdminClient().PausingV1alpha1().ScyllaDBDatacenterPools(ns.GetName()).Create(ctx, sdcp, metav1.CreateOptions{})
+		o.Expect(err).NotTo(o.HaveOccurred())
+
+		framework.By("Creating a PausableScyllaDBDatacenter in an unpaused state")
+		psdc := getPausableScyllaDBDatacenter("basic", sdcp.GetName())
+		psdc, err = c.PausingAdminClient().PausingV1alpha1().PausableScyllaDBDatacenters(ns.GetName()).Create(ctx, psdc, metav1.CreateOptions{})
+		o.Expect(err).NotTo(o.HaveOccurred())
+
+		framework.By("Waiting for PausableScyllaDBDatacenter to roll out")
+		psdc, err = psocontrollerhelpers.WaitForPausableScyllaDBDatacenterState(ctx, c.PausingAdminClient().PausingV1alpha1().PausableScyllaDBDatacenters(ns.GetName()), psdc.GetName(), socontrollerhelpers.WaitForStateOptions{}, psocontrollerhelpers.IsPausableScyllaDBDatacenterRolledOut)
+		o.Expect(err).NotTo(o.HaveOccurred())
+
+		framework.By("Waiting for ScyllaDBDatacenterPool to be at capacity")
+		_, err = psocontrollerhelpers.WaitForScyllaDBDatacenterPoolState(ctx, c.PausingAdminClient().PausingV1alpha1().ScyllaDBDatacenterPools(ns.GetName()), sdcp.GetName(), socontrollerhelpers.WaitForStateOptions{}, psocontrollerhelpers.IsScyllaDBDatacenterPoolRolledOut, isScyllaDBDatacenterPoolAtCapacity)
+		o.Expect(err).NotTo(o.HaveOccurred())
+
+		sdc, err := getBoundScyllaDBDatacenter(ctx, c, ns.GetName(), psdc)
+		o.Expect(err).NotTo(o.HaveOccurred())
+
+		connectionBundleDir, err := os.MkdirTemp(os.TempDir(), fmt.Sprintf("connection-bundle-%s-", ns.GetName()))
+		o.Expect(err).NotTo(o.HaveOccurred())
+		defer func() {
+			err := os.RemoveAll(connectionBundleDir)
+			o.Expect(err).NotTo(o.HaveOccurred())
+		}()
+
+		cqlConnectionConfigFilePath, err := writeCQLConnectionConfig(ctx, c, sdc, connectionBundleDir)
+		o.Expect(err).NotTo(o.HaveOccurred())
+
+		cluster, err := newCloudCluster(cqlConnectionConfigFilePath)
+		o.Expect(err).NotTo(o.HaveOccurred())
+
+		var integrityManifest *integrity.Manifest
+		if integrityPartitions > 0 {
+			session, err := cluster.CreateSession()
+			o.Expect(err).NotTo(o.HaveOccurred())
+
+			integrityManifest, err = writeIntegrityData(ctx, session, len(getRackMembers(sdc)))
+			session.Close()
+			o.Expect(err).NotTo(o.HaveOccurred())
+		}
+
+		resourceCounter := newNamespaceResourceCounter(c, ns.GetName())
+		expectedCounts, err := resourceCounter.count(ctx)
+		o.Expect(err).NotTo(o.HaveOccurred())
+		framework.Infof("Objects before the first cycle: %v.", expectedCounts)
+
+		var pauseTimesMs, unpauseTimesMs []float64
+		for cycle := range soakCycles {
+			labels := map[string]string{
+				cycleLabel: strconv.Itoa(cycle),
+			}
+
+			framework.By("Pausing PausableScyllaDBDatacenter in cycle %d", cycle)
+			pauseStopwatch := timeline.NewStopwatch(time.Now())
+			psdc, err = c.PausingAdminClient().PausingV1alpha1().PausableScyllaDBDatacenters(ns.GetName()).Patch(
+				ctx,
+				psdc.Name,
+				types.JSONPatchType,
+				[]byte(`[{"op": "replace", "path": "/spec/paused", "value": true}]`),
+				metav1.PatchOptions{},
+			)
+			o.Expect(err).NotTo(o.HaveOccurred())
+
+			psdc, err = psocontrollerhelpers.WaitForPausableScyllaDBDatacenterState(ctx, c.PausingAdminClient().PausingV1alpha1().PausableScyllaDBDatacenters(ns.GetName()), psdc.GetName(), socontrollerhelpers.WaitForStateOptions{}, psocontrollerhelpers.IsPausableScyllaDBDatacenterRolledOut)
+			o.Expect(err).NotTo(o.HaveOccurred())
+			pauseStopwatch.Lap(pausedRolledOutMilestone)
+
+			err = soframework.WaitForObjectDeletion(
+				ctx,
+				nsClient.DynamicClient(),
+				scyllav1alpha1.GroupVersion.WithResource("scylladbdatacenters"),
+				ns.GetName(),
+				sdc.GetName(),
+				ptr.To(sdc.GetUID()),
+			)
+			o.Expect(err).NotTo(o.HaveOccurred())
+			pauseStopwatch.Lap(scyllaDBDatacenterDeletedMilestone)
+
+			pauseRes := results.NewRecord(runMetadata, resultsName+"-pause", pauseStopwatch.StartTime(), pauseStopwatch.LastTime())
+			pauseRes.Labels = labels
+			pauseRes.Phases = pauseStopwatch.Phases()
+			err = pauseResultSink.Write(ctx, pauseRes)
+			o.Expect(err).NotTo(o.HaveOccurred())
+			pauseTimesMs = append(pauseTimesMs, float64(pauseRes.ElapsedTimeMs))
+
+			framework.By("Waiting for ScyllaDBDatacenterPool to be at capacity")
+			_, err = psocontrollerhelpers.WaitForScyllaDBDatacenterPoolState(ctx, c.PausingAdminClient().PausingV1alpha1().ScyllaDBDatacenterPools(ns.GetName()), sdcp.GetName(), socontrollerhelpers.WaitForStateOptions{}, psocontrollerhelpers.IsScyllaDBDatacenterPoolRolledOut, isScyllaDBDatacenterPoolAtCapacity)
+			o.Expect(err).NotTo(o.HaveOccurred())
+
+			framework.By("Connecting to the paused cluster via Ingress in cycle %d", cycle)
+			startTime := time.Now()
+			session, err := cluster.CreateSession()
+			stopTime := time.Now()
+			o.Expect(err).NotTo(o.HaveOccurred())
+
+			var integrityResult *results.Integrity
+			if integrityManifest != nil {
+				integrityResult, err = verifyIntegrity(ctx, session, integrityManifest)
+			}
+			session.Close()
+			o.Expect(err).NotTo(o.HaveOccurred())
+
+			psdc, err = psocontrollerhelpers.WaitForPausableScyllaDBDatacenterState(ctx, c.PausingAdminClient().PausingV1alpha1().PausableScyllaDBDatacenters(ns.GetName()), psdc.GetName(), socontrollerhelpers.WaitForStateOptions{}, psocontrollerhelpers.IsPausableScyllaDBDatacenterRolledOut)
+			o.Expect(err).NotTo(o.HaveOccurred())
+
+			sdc, err = getBoundScyllaDBDatacenter(ctx, c, ns.GetName(), psdc)
+			o.Expect(err).NotTo(o.HaveOccurred())
+
+			nodeTimings := getScyllaNodeTimings(ctx, nsClient.KubeClient().CoreV1().Pods(ns.GetName()), sdc)
+			o.Expect(nodeTimings).NotTo(o.BeEmpty())
+
+			res := results.NewRecord(runMetadata, resultsName, startTime, stopTime)
+			res.Labels = labels
+			setApplicationTimeline(res, nodeTimings)
+			res.Integrity = integrityResult
+			err = unpauseResultSink.Write(ctx, res)
+			o.Expect(err).NotTo(o.HaveOccurred())
+
+			if integrityResult != nil {
+				o.Expect(integrityResult.MissingPartitions).To(o.BeEmpty(), "partitions are missing after cycle %d", cycle)
+				o.Expect(integrityResult.CorruptPartitions).To(o.BeEmpty(), "partitions are corrupt after cycle %d", cycle)
+			}
+			unpauseTimesMs = append(unpauseTimesMs, float64(res.ElapsedTimeMs))
+			framework.Infof("Cycle %d paused after %dms and unpaused after %dms.", cycle, pauseRes.ElapsedTimeMs, res.ElapsedTimeMs)
+
+			framework.By("Waiting for ScyllaDBDatacenterPool to be replenished")
+			_, err = psocontrollerhelpers.WaitForScyllaDBDatacenterPoolState(ctx, c.PausingAdminClient().PausingV1alpha1().ScyllaDBDatacenterPools(ns.GetName()), sdcp.GetName(), socontrollerhelpers.WaitForStateOptions{}, psocontrollerhelpers.IsScyllaDBDatacenterPoolRolledOut, isScyllaDBDatacenterPoolAtCapacity)
+			o.Expect(err).NotTo(o.HaveOccurred())
+
+			framework.By("Checking for leaked objects after cycle %d", cycle)
+			counts, err := resourceCounter.waitForCounts(ctx, expectedCounts)
+			o.Expect(err).NotTo(o.HaveOccurred(), "objects didn't return to the expected counts after cycle %d.\nExpected: %v.\nGot: %v.", cycle, expectedCounts, counts)
+		}
+
+		if soakCycles > 1 {
+			framework.Infof("Pause time changed by %.1fms per cycle and unpause time by %.1fms per cycle.", stats.Trend(pauseTimesMs), stats.Trend(unpauseTimesMs))
+		}
+	})
+})
@@ -0,0 +1,348 @@
+package pausable_scylladb_operator_benchmarks_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pausing-clusters-thesis/benchmarks/framework"
+	"github.com/pausing-clusters-thesis/benchmarks/timeline"
+	pausingv1alpha1 "github.com/pausing-clusters-thesis/pausable-scylladb-operator/pkg/api/pausing/v1alpha1"
+	psocontrollerhelpers "github.com/pausing-clusters-thesis/pausable-scylladb-operator/pkg/controllerhelpers"
+	psonaming "github.com/pausing-clusters-thesis/pausable-scylladb-operator/pkg/naming"
+	proxycsinaming "github.com/pausing-clusters-thesis/proxy-csi-driver/pkg/naming"
+	scyllav1alpha1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1alpha1"
+	sonaming "github.com/scylladb/scylla-operator/pkg/naming"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	claimBoundMilestone                 = "claim-bound"
+	scyllaDBDatacenterCreatedMilestone  = "scylladb-datacenter-created"
+	podsScheduledMilestone              = "pods-scheduled"
+	backendVolumesAttachedMilestone     = "backend-volumes-attached"
+	proxyVolumesMountedMilestone        = "proxy-volumes-mounted"
+	scyllaContainersStartedMilestone    = "scylla-containers-started"
+	servingMilestone                    = "serving"
+	ingressReadinessGatesReadyMilestone = "ingress-readiness-gates-ready"
+)
+
+const (
+	pausedRolledOutMilestone                 = "paused-rolled-out"
+	scyllaDBDatacenterDeletedMilestone       = "scylladb-datacenter-deleted"
+	backendVolumesUnboundMilestone           = "backend-volumes-unbound"
+	backendVolumeAttachmentsDeletedMilestone = "backend-volume-attachments-deleted"
+)
+
+// unpauseObserver inspects the state of objects backing a PausableScyllaDBDatacenter while it's being unpaused.
+// Member Pods and their backend volumes are read from informer caches, so that polling the milestones doesn't flood
+// the API server with requests during the measurement. Conditions are only evaluated by a single tracker, one at a time.
+type unpauseObserver struct {
+	cluster   *framework.Cluster
+	namespace string
+	psdc      *pausingv1alpha1.PausableScyllaDBDatacenter
+	podLister corev1listers.PodLister
+	pvcLister corev1listers.PersistentVolumeClaimLister
+	pvLister  corev1listers.PersistentVolumeLister
+	// vaIndexer holds VolumeAttachments by name, as they are cluster-scoped.
+	vaIndexer cache.Indexer
+
+	// sdc is the bound ScyllaDBDatacenter, cached once it's found as the binding doesn't change while unpausing.
+	sdc *scyllav1alpha1.ScyllaDBDatacenter
+}
+
+// newUnpauseObserver creates an unpauseObserver with Pod, PersistentVolumeClaim, PersistentVolume and VolumeAttachment
+// informers running until the context is done.
+func newUnpauseObserver(ctx context.Context, c *framework.Cluster, namespace string, psdc *pausingv1alpha1.PausableScyllaDBDatacenter) (*unpauseObserver, error) {
+	podInformer := newInformer(c.KubeAdminClient().CoreV1().RESTClient(), "pods", namespace, &corev1.Pod{})
+	pvcInformer := newInformer(c.KubeAdminClient().CoreV1().RESTClient(), "persistentvolumeclaims", namespace, &corev1.PersistentVolumeClaim{})
+	pvInformer := newInformer(c.KubeAdminClient().CoreV1().RESTClient(), "persistentvolumes", metav1.NamespaceAll, &corev1.PersistentVolume{})
+	vaInformer := newInformer(c.KubeAdminClient().StorageV1().RESTClient(), "volumeattachments", metav1.NamespaceAll, &storagev1.VolumeAttachment{})
+
+	informers := []cache.SharedIndexInformer{podInformer, pvcInformer, pvInformer, vaInformer}
+	hasSynced := make([]cache.InformerSynced, 0, len(informers))
+	for _, informer := range informers {
+		go informer.Run(ctx.Done())
+		hasSynced = append(hasSynced, informer.HasSynced)
+	}
+
+	if !cache.WaitForCacheSync(ctx.Done(), hasSynced...) {
+		return nil, fmt.Errorf("can't sync informers of namespace %q", namespace)
+	}
+
+	return &unpauseObserver{
+		cluster:   c,
+		namespace: namespace,
+		psdc:      psdc,
+		podLister: corev1listers.NewPodLister(podInformer.GetIndexer()),
+		pvcLister: corev1listers.NewPersistentVolumeClaimLister(pvcInformer.GetIndexer()),
+		pvLister:  corev1listers.NewPersistentVolumeLister(pvInformer.GetIndexer()),
+		vaIndexer: vaInformer.GetIndexer(),
+	}, nil
+}
+
+// newInformer returns an informer of the resource in the namespace, or of all objects if the namespace is empty.
+func newInformer(client cache.Getter, resource string, namespace string, objType runtime.Object) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		cache.NewListWatchFromClient(client, resource, namespace, fields.Everything()),
+		objType,
+		0,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+	)
+}
+
+func (uo *unpauseObserver) milestones() []timeline.Milestone {
+	return []timeline.Milestone{
+		{
+			Name:      claimBoundMilestone,
+			Condition: uo.isClaimBound,
+		},
+		{
+			Name:      scyllaDBDatacenterCreatedMilestone,
+			Condition: uo.isScyllaDBDatacenterCreated,
+		},
+		{
+			Name:      podsScheduledMilestone,
+			Condition: uo.forEachMemberPod(isPodScheduled),
+		},
+		{
+			Name:      backendVolumesAttachedMilestone,
+			Condition: uo.areBackendVolumesAttached,
+		},
+		{
+			Name:      proxyVolumesMountedMilestone,
+			Condition: uo.forEachMemberPod(isProxyVolumeMounted),
+		},
+		{
+			Name:      scyllaContainersStartedMilestone,
+			Condition: uo.forEachMemberPod(isScyllaContainerStarted),
+		},
+		{
+			Name:      servingMilestone,
+			Condition: uo.forEachMemberPod(isScyllaContainerReady),
+		},
+		{
+			Name:      ingressReadinessGatesReadyMilestone,
+			Condition: uo.forEachMemberPod(isIngressReadinessGateReady),
+		},
+	}
+}
+
+func (uo *unpauseObserver) getBoundScyllaDBDatacenterName(ctx context.Context) (string, error) {
+	sdcc, err := uo.cluster.PausingAdminClient().PausingV1alpha1().ScyllaDBDatacenterClaims(uo.namespace).Get(ctx, psonaming.GetScyllaDBDatacenterClaimNameForPausableScyllaDBDatacenter(uo.psdc), metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	if sdcc.Status.ScyllaDBDatacenterName == nil {
+		return "", nil
+	}
+
+	return *sdcc.Status.ScyllaDBDatacenterName, nil
+}
+
+func (uo *unpauseObserver) getScyllaDBDatacenter(ctx context.Context) (*scyllav1alpha1.ScyllaDBDatacenter, error) {
+	if uo.sdc != nil {
+		return uo.sdc, nil
+	}
+
+	sdcName, err := uo.getBoundScyllaDBDatacenterName(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sdcName) == 0 {
+		return nil, nil
+	}
+
+	sdc, err := uo.cluster.ScyllaAdminClient().ScyllaV1alpha1().ScyllaDBDatacenters(uo.namespace).Get(ctx, sdcName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	uo.sdc = sdc
+
+	return sdc, nil
+}
+
+type memberPod struct {
+	rackName  string
+	memberIdx int32
+	pod       *corev1.Pod
+}
+
+// getMemberPods returns member Pods of the bound ScyllaDBDatacenter, or nil if any of them doesn't exist yet.
+func (uo *unpauseObserver) getMemberPods(ctx context.Context) ([]memberPod, error) {
+	sdc, err := uo.getScyllaDBDatacenter(ctx)
+	if err != nil || sdc == nil {
+		return nil, err
+	}
+
+	rackMembers, err := listRackMembers(sdc)
+	if err != nil {
+		return nil, err
+	}
+
+	var members []memberPod
+	for _, m := range rackMembers {
+		pod, err := uo.podLister.Pods(uo.namespace).Get(sonaming.MemberServiceName(m.rack, sdc, int(m.idx)))
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		members = append(members, memberPod{
+			rackName:  m.rack.Name,
+			memberIdx: m.idx,
+			pod:       pod,
+		})
+	}
+
+	return members, nil
+}
+
+func (uo *unpauseObserver) forEachMemberPod(condition func(*corev1.Pod) bool) func(context.Context) (bool, error) {
+	return func(ctx context.Context) (bool, error) {
+		members, err := uo.getMemberPods(ctx)
+		if err != nil || len(members) == 0 {
+			return false, err
+		}
+
+		for _, m := range members {
+			if !condition(m.pod) {
+				return false, nil
+			}
+		}
+
+		return true, nil
+	}
+}
+
+func (uo *unpauseObserver) isClaimBound(ctx context.Context) (bool, error) {
+	sdcName, err := uo.getBoundScyllaDBDatacenterName(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return len(sdcName) != 0, nil
+}
+
+func (uo *unpauseObserver) isScyllaDBDatacenterCreated(ctx context.Context) (bool, error) {
+	sdc, err := uo.getScyllaDBDatacenter(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return sdc != nil, nil
+}
+
+func (uo *unpauseObserver) areBackendVolumesAttached(ctx context.Context) (bool, error) {
+	members, err := uo.getMemberPods(ctx)
+	if err != nil || len(members) == 0 {
+		return false, err
+	}
+
+	for _, m := range members {
+		if len(m.pod.Spec.NodeName) == 0 {
+			return false, nil
+		}
+
+		backendPVCName := psonaming.GetBackendPersistentVolumeClaimNameForPausableScyllaDBDatacenterMember(uo.psdc.Name, m.rackName, m.memberIdx)
+		backendPVC, err := uo.pvcLister.PersistentVolumeClaims(uo.namespace).Get(backendPVCName)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+
+		if len(backendPVC.Spec.VolumeName) == 0 {
+			return false, nil
+		}
+
+		backendPV, err := uo.pvLister.Get(backendPVC.Spec.VolumeName)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+
+		if backendPV.Spec.CSI == nil {
+			return false, fmt.Errorf("backend PV %q is not a CSI volume", backendPV.Name)
+		}
+
+		backendVAName := getAttachmentName(backendPV.Spec.CSI.VolumeHandle, backendPV.Spec.CSI.Driver, m.pod.Spec.NodeName)
+		obj, exists, err := uo.vaIndexer.GetByKey(backendVAName)
+		if err != nil {
+			return false, err
+		}
+
+		if !exists {
+			return false, nil
+		}
+
+		backendVA, ok := obj.(*storagev1.VolumeAttachment)
+		if !ok {
+			return false, fmt.Errorf("unexpected object of type %T in VolumeAttachment cache", obj)
+		}
+
+		if !backendVA.Status.Attached {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func isPodScheduled(pod *corev1.Pod) bool {
+	return len(pod.Spec.NodeName) != 0
+}
+
+func isProxyVolumeMounted(pod *corev1.Pod) bool {
+	return psocontrollerhelpers.HasMatchingAnnotation(pod, fmt.Sprintf(proxycsinaming.DelayedStorageMountedAnnotationFormat, sonaming.PVCTemplateName), proxycsinaming.DelayedStorageMountedAnnotationTrue)
+}
+
+func getScyllaContainerStatus(pod *corev1.Pod) *corev1.ContainerStatus {
+	for i := range pod.Status.ContainerStatuses {
+		if pod.Status.ContainerStatuses[i].Name == sonaming.ScyllaContainerName {
+			return &pod.Status.ContainerStatuses[i]
+		}
+	}
+
+	return nil
+}
+
+func isScyllaContainerStarted(pod *corev1.Pod) bool {
+	cs := getScyllaContainerStatus(pod)
+	return cs != nil && cs.State.Running != nil
+}
+
+func isScyllaContainerReady(pod *corev1.Pod) bool {
+	cs := getScyllaContainerStatus(pod)
+	return cs != nil && cs.Ready
+}
+
+func isIngressReadinessGateReady(pod *corev1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == psonaming.IngressControllerScyllaDBMemberPodConditionType {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,193 @@
+package preload
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+const (
+	DefaultKeyspace     = "preload"
+	DefaultRowSizeBytes = 64 * 1024
+	DefaultConcurrency  = 32
+)
+
+// Options describe the data written before a cluster is paused.
+// SizeBytes is the logical size of the data, before replication.
+type Options struct {
+	Keyspace          string
+	SizeBytes         int64
+	Tables            int
+	RowSizeBytes      int
+	Concurrency       int
+	ReplicationFactor int
+	Seed              uint64
+}
+
+func (o *Options) Validate() error {
+	var errs []error
+
+	if len(o.Keyspace) == 0 {
+		errs = append(errs, errors.New("keyspace must not be empty"))
+	}
+
+	if o.SizeBytes < 0 {
+		errs = append(errs, fmt.Errorf("size must not be negative, got %d", o.SizeBytes))
+	}
+
+	if o.Tables <= 0 {
+		errs = append(errs, fmt.Errorf("tables must be greater than zero, got %d", o.Tables))
+	}
+
+	if o.RowSizeBytes <= 0 {
+		errs = append(errs, fmt.Errorf("row size must be greater than zero, got %d", o.RowSizeBytes))
+	}
+
+	if o.Concurrency <= 0 {
+		errs = append(errs, fmt.Errorf("concurrency must be greater than zero, got %d", o.Concurrency))
+	}
+
+	if o.ReplicationFactor <= 0 {
+		errs = append(errs, fmt.Errorf("replication factor must be greater than zero, got %d", o.ReplicationFactor))
+	}
+
+	return errors.Join(errs...)
+}
+
+// RowsPerTable returns the number of rows in each table needed to reach at least the requested size.
+func (o *Options) RowsPerTable() int64 {
+	rowsPerTable := o.SizeBytes / int64(o.RowSizeBytes) / int64(o.Tables)
+	if rowsPerTable*int64(o.RowSizeBytes)*int64(o.Tables) < o.SizeBytes {
+		rowsPerTable++
+	}
+
+	return rowsPerTable
+}
+
+func TableName(idx int) string {
+	return fmt.Sprintf("data_%d", idx)
+}
+
+// Payload returns the deterministic content of a row, so that the data can be verified after the cluster is unpaused.
+func Payload(seed uint64, table int, id int64, size int) []byte {
+	r := rand.New(rand.NewPCG(seed^uint64(table), uint64(id)))
+
+	payload := make([]byte, size)
+	for i := 0; i < size; i += 8 {
+		var buf [8]byte
+		binary.LittleEndian.PutUint64(buf[:], r.Uint64())
+		copy(payload[i:], buf[:])
+	}
+
+	return payload
+}
+
+type Result struct {
+	Rows     int64
+	Bytes    int64
+	Duration time.Duration
+}
+
+type row struct {
+	table int
+	id    int64
+}
+
+// Run creates the keyspace and tables and fills them with rows until the requested size is written.
+func Run(ctx context.Context, session *gocql.Session, options Options) (*Result, error) {
+	err := options.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid preload options: %w", err)
+	}
+
+	startTime := time.Now()
+
+	err = session.Query(fmt.Sprintf(
+		`CREATE KEYSPACE IF NOT EXISTS %q WITH replication = {'class': 'NetworkTopologyStrategy', 'replication_factor': %d}`,
+		options.Keyspace,
+		options.ReplicationFactor,
+	)).WithContext(ctx).Exec()
+	if err != nil {
+		return nil, fmt.Errorf("can't create keyspace %q: %w", options.Keyspace, err)
+	}
+
+	for i := range options.Tables {
+		err = session.Query(fmt.Sprintf(
+			`CREATE TABLE IF NOT EXISTS %q.%q (id bigint PRIMARY KEY, payload blob)`,
+			options.Keyspace,
+			TableName(i),
+		)).WithContext(ctx).Exec()
+		if err != nil {
+			return nil, fmt.Errorf("can't create table %q: %w", TableName(i), err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	rowsPerTable := options.RowsPerTable()
+	rowCh := make(chan row)
+	go func() {
+		defer close(rowCh)
+		for id := range rowsPerTable {
+			for table := range options.Tables {
+				select {
+				case rowCh <- row{table: table, id: id}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	var writtenRows atomic.Int64
+	var errOnce sync.Once
+	var firstErr error
+	var wg sync.WaitGroup
+	for range options.Concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for r := range rowCh {
+				err := session.Query(
+					fmt.Sprintf(`INSERT INTO %q.%q (id, payload) VALUES (?, ?)`, options.Keyspace, TableName(r.table)),
+					r.id,
+					Payload(options.Seed, r.table, r.id, options.RowSizeBytes),
+				).WithContext(ctx).Exec()
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = fmt.Errorf("can't insert row %d into table %q: %w", r.id, TableName(r.table), err)
+						cancel()
+					})
+					return
+				}
+
+				writtenRows.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	err = ctx.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		Rows:     writtenRows.Load(),
+		Bytes:    writtenRows.Load() * int64(options.RowSizeBytes),
+		Duration: time.Since(startTime),
+	}, nil
+}
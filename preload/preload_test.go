@@ -0,0 +1,93 @@
+package preload
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestOptionsRowsPerTable(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name     string
+		options  Options
+		expected int64
+	}{
+		{
+			name:     "no data",
+			options:  Options{SizeBytes: 0, Tables: 4, RowSizeBytes: 1024},
+			expected: 0,
+		},
+		{
+			name:     "evenly divisible",
+			options:  Options{SizeBytes: 1 << 30, Tables: 4, RowSizeBytes: 64 * 1024},
+			expected: 4096,
+		},
+		{
+			name:     "rounded up to reach the size",
+			options:  Options{SizeBytes: 10*1024 + 1, Tables: 3, RowSizeBytes: 1024},
+			expected: 4,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := tc.options.RowsPerTable()
+			if got != tc.expected {
+				t.Errorf("expected %d rows per table, got %d", tc.expected, got)
+			}
+
+			if got*int64(tc.options.Tables)*int64(tc.options.RowSizeBytes) < tc.options.SizeBytes {
+				t.Errorf("expected %d rows per table to reach %d bytes", got, tc.options.SizeBytes)
+			}
+		})
+	}
+}
+
+func TestOptionsValidate(t *testing.T) {
+	t.Parallel()
+
+	valid := Options{
+		Keyspace:          DefaultKeyspace,
+		SizeBytes:         1024,
+		Tables:            1,
+		RowSizeBytes:      DefaultRowSizeBytes,
+		Concurrency:       DefaultConcurrency,
+		ReplicationFactor: 3,
+	}
+	err := valid.Validate()
+	if err != nil {
+		t.Errorf("expected valid options, got %v", err)
+	}
+
+	invalid := valid
+	invalid.Keyspace = ""
+	invalid.SizeBytes = -1
+	invalid.Tables = 0
+	invalid.ReplicationFactor = 0
+	err = invalid.Validate()
+	if err == nil {
+		t.Errorf("expected an error for invalid options")
+	}
+}
+
+func TestPayload(t *testing.T) {
+	t.Parallel()
+
+	payload := Payload(42, 1, 7, 100)
+	if len(payload) != 100 {
+		t.Fatalf("expected payload of 100 bytes, got %d", len(payload))
+	}
+
+	if !bytes.Equal(payload, Payload(42, 1, 7, 100)) {
+		t.Errorf("expected payload to be deterministic")
+	}
+
+	for _, other := range [][]byte{Payload(43, 1, 7, 100), Payload(42, 2, 7, 100), Payload(42, 1, 8, 100)} {
+		if bytes.Equal(payload, other) {
+			t.Errorf("expected payloads of different rows to differ")
+		}
+	}
+}
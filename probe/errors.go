@@ -0,0 +1,100 @@
+package probe
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/gocql/gocql"
+)
+
+// ErrorClass groups errors returned by gocql by their cause.
+type ErrorClass string
+
+const (
+	NoHostsErrorClass          ErrorClass = "no-hosts"
+	NoConnectionsErrorClass    ErrorClass = "no-connections"
+	SessionCreationErrorClass  ErrorClass = "session-creation"
+	ResponseTimeoutErrorClass  ErrorClass = "response-timeout"
+	ConnectionClosedErrorClass ErrorClass = "connection-closed"
+	UnavailableErrorClass      ErrorClass = "unavailable"
+	OverloadedErrorClass       ErrorClass = "overloaded"
+	BootstrappingErrorClass    ErrorClass = "bootstrapping"
+	WriteTimeoutErrorClass     ErrorClass = "write-timeout"
+	ReadTimeoutErrorClass      ErrorClass = "read-timeout"
+	ServerErrorClass           ErrorClass = "server-error"
+	DeadlineExceededErrorClass ErrorClass = "deadline-exceeded"
+	CanceledErrorClass         ErrorClass = "canceled"
+	NetworkTimeoutErrorClass   ErrorClass = "network-timeout"
+	DialErrorClass             ErrorClass = "dial"
+	NetworkErrorClass          ErrorClass = "network"
+	OtherErrorClass            ErrorClass = "other"
+)
+
+const sessionCreationErrorSubstring = "unable to create session"
+
+var sentinelErrorClasses = []struct {
+	err   error
+	class ErrorClass
+}{
+	{err: gocql.ErrNoHosts, class: NoHostsErrorClass},
+	{err: gocql.ErrNoConnectionsStarted, class: NoConnectionsErrorClass},
+	{err: gocql.ErrNoConnections, class: NoConnectionsErrorClass},
+	{err: gocql.ErrTimeoutNoResponse, class: ResponseTimeoutErrorClass},
+	{err: gocql.ErrConnectionClosed, class: ConnectionClosedErrorClass},
+	{err: context.DeadlineExceeded, class: DeadlineExceededErrorClass},
+	{err: context.Canceled, class: CanceledErrorClass},
+}
+
+var requestErrorClasses = map[int]ErrorClass{
+	gocql.ErrCodeUnavailable:   UnavailableErrorClass,
+	gocql.ErrCodeOverloaded:    OverloadedErrorClass,
+	gocql.ErrCodeBootstrapping: BootstrappingErrorClass,
+	gocql.ErrCodeWriteTimeout:  WriteTimeoutErrorClass,
+	gocql.ErrCodeReadTimeout:   ReadTimeoutErrorClass,
+}
+
+// ClassifyError returns the class of an error returned by gocql, or an empty class for no error.
+func ClassifyError(err error) ErrorClass {
+	if err == nil {
+		return ""
+	}
+
+	for _, sc := range sentinelErrorClasses {
+		if errors.Is(err, sc.err) {
+			return sc.class
+		}
+	}
+
+	var requestErr gocql.RequestError
+	if errors.As(err, &requestErr) {
+		class, ok := requestErrorClasses[requestErr.Code()]
+		if ok {
+			return class
+		}
+
+		return ServerErrorClass
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return NetworkTimeoutErrorClass
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		if opErr.Op == "dial" {
+			return DialErrorClass
+		}
+
+		return NetworkErrorClass
+	}
+
+	// gocql doesn't wrap the causes of failing to create a session.
+	if strings.Contains(err.Error(), sessionCreationErrorSubstring) {
+		return SessionCreationErrorClass
+	}
+
+	return OtherErrorClass
+}
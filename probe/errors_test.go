@@ -0,0 +1,105 @@
+package probe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/gocql/gocql"
+)
+
+type fakeRequestError struct {
+	code int
+}
+
+func (e *fakeRequestError) Code() int {
+	return e.code
+}
+
+func (e *fakeRequestError) Message() string {
+	return "fake"
+}
+
+func (e *fakeRequestError) Error() string {
+	return e.Message()
+}
+
+type fakeTimeoutError struct{}
+
+func (fakeTimeoutError) Error() string   { return "i/o timeout" }
+func (fakeTimeoutError) Timeout() bool   { return true }
+func (fakeTimeoutError) Temporary() bool { return true }
+
+func TestClassifyError(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name     string
+		err      error
+		expected ErrorClass
+	}{
+		{
+			name:     "no error",
+			err:      nil,
+			expected: "",
+		},
+		{
+			name:     "no connections when creating a session",
+			err:      gocql.ErrNoConnectionsStarted,
+			expected: NoConnectionsErrorClass,
+		},
+		{
+			name:     "wrapped response timeout",
+			err:      fmt.Errorf("can't query: %w", gocql.ErrTimeoutNoResponse),
+			expected: ResponseTimeoutErrorClass,
+		},
+		{
+			name:     "unavailable replicas",
+			err:      &fakeRequestError{code: gocql.ErrCodeUnavailable},
+			expected: UnavailableErrorClass,
+		},
+		{
+			name:     "unknown server error code",
+			err:      &fakeRequestError{code: gocql.ErrCodeSyntax},
+			expected: ServerErrorClass,
+		},
+		{
+			name:     "context deadline",
+			err:      context.DeadlineExceeded,
+			expected: DeadlineExceededErrorClass,
+		},
+		{
+			name:     "network timeout",
+			err:      &net.OpError{Op: "read", Err: fakeTimeoutError{}},
+			expected: NetworkTimeoutErrorClass,
+		},
+		{
+			name:     "dial failure",
+			err:      &net.OpError{Op: "dial", Err: errors.New("connection refused")},
+			expected: DialErrorClass,
+		},
+		{
+			name:     "unwrapped session creation failure",
+			err:      fmt.Errorf("gocql: unable to create session: %v", errors.New("control: unable to connect to initial hosts")),
+			expected: SessionCreationErrorClass,
+		},
+		{
+			name:     "unknown",
+			err:      errors.New("unknown"),
+			expected: OtherErrorClass,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ClassifyError(tc.err)
+			if got != tc.expected {
+				t.Errorf("expected class %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
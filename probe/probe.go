@@ -0,0 +1,200 @@
+package probe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+const (
+	DefaultKeyspace = "probe"
+	DefaultInterval = 500 * time.Millisecond
+
+	tableName = "writes"
+)
+
+type Stage string
+
+const (
+	ConnectStage Stage = "connect"
+	QueryStage   Stage = "query"
+	WriteStage   Stage = "write"
+)
+
+// Attempt is the outcome of a single probe. Stage is the last stage the attempt got to.
+type Attempt struct {
+	StartTime  time.Time
+	Duration   time.Duration
+	Stage      Stage
+	Err        error
+	ErrorClass ErrorClass
+}
+
+func (a *Attempt) Succeeded() bool {
+	return a.Err == nil
+}
+
+type Options struct {
+	Keyspace string
+	// Consistency is the consistency level of the writes.
+	Consistency gocql.Consistency
+	// Interval is the time between the starts of consecutive attempts.
+	// An attempt that takes longer than the interval is followed by the next one immediately.
+	Interval time.Duration
+	// OnAttempt is called after every attempt (optional).
+	OnAttempt func(Attempt)
+}
+
+func (o *Options) Validate() error {
+	var errs []error
+
+	if len(o.Keyspace) == 0 {
+		errs = append(errs, errors.New("keyspace must not be empty"))
+	}
+
+	if o.Interval <= 0 {
+		errs = append(errs, fmt.Errorf("interval must be greater than zero, got %v", o.Interval))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Prepare creates the table the probe writes to. It has to be called while the cluster is available.
+func Prepare(ctx context.Context, session *gocql.Session, keyspace string, replicationFactor int) error {
+	err := session.Query(fmt.Sprintf(
+		`CREATE KEYSPACE IF NOT EXISTS %q WITH replication = {'class': 'NetworkTopologyStrategy', 'replication_factor': %d}`,
+		keyspace,
+		replicationFactor,
+	)).WithContext(ctx).Exec()
+	if err != nil {
+		return fmt.Errorf("can't create keyspace %q: %w", keyspace, err)
+	}
+
+	err = session.Query(fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %q.%q (id timeuuid PRIMARY KEY)`, keyspace, tableName)).WithContext(ctx).Exec()
+	if err != nil {
+		return fmt.Errorf("can't create table %q: %w", tableName, err)
+	}
+
+	return nil
+}
+
+// Prober repeatedly opens a new CQL session, runs a lightweight query and writes a row with the configured consistency,
+// until a write succeeds.
+type Prober struct {
+	cluster *gocql.ClusterConfig
+	options Options
+}
+
+func NewProber(cluster *gocql.ClusterConfig, options Options) (*Prober, error) {
+	err := options.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid probe options: %w", err)
+	}
+
+	return &Prober{
+		cluster: cluster,
+		options: options,
+	}, nil
+}
+
+// Run probes the cluster until the first successful write or until ctx is done.
+func (p *Prober) Run(ctx context.Context) (*Report, error) {
+	report := &Report{
+		StartTime: time.Now(),
+	}
+
+	for {
+		attempt := p.attempt(ctx)
+		if ctx.Err() != nil {
+			return report, fmt.Errorf("can't get a successful %v write after %d attempts: %w", p.options.Consistency, len(report.Attempts), ctx.Err())
+		}
+
+		report.add(attempt)
+		if p.options.OnAttempt != nil {
+			p.options.OnAttempt(attempt)
+		}
+
+		if attempt.Succeeded() {
+			return report, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return report, fmt.Errorf("can't get a successful %v write after %d attempts: %w", p.options.Consistency, len(report.Attempts), ctx.Err())
+		case <-time.After(time.Until(attempt.StartTime.Add(p.options.Interval))):
+		}
+	}
+}
+
+func (p *Prober) attempt(ctx context.Context) Attempt {
+	attempt := Attempt{
+		StartTime: time.Now(),
+		Stage:     ConnectStage,
+	}
+
+	fail := func(err error) Attempt {
+		attempt.Duration = time.Since(attempt.StartTime)
+		attempt.Err = err
+		attempt.ErrorClass = ClassifyError(err)
+		return attempt
+	}
+
+	session, err := p.cluster.CreateSession()
+	if err != nil {
+		return fail(err)
+	}
+	defer session.Close()
+
+	attempt.Stage = QueryStage
+	err = session.Query(`SELECT now() FROM system.local`).Consistency(gocql.One).WithContext(ctx).Exec()
+	if err != nil {
+		return fail(err)
+	}
+
+	attempt.Stage = WriteStage
+	err = session.Query(fmt.Sprintf(`INSERT INTO %q.%q (id) VALUES (now())`, p.options.Keyspace, tableName)).Consistency(p.options.Consistency).WithContext(ctx).Exec()
+	if err != nil {
+		return fail(err)
+	}
+
+	attempt.Duration = time.Since(attempt.StartTime)
+	return attempt
+}
+
+type Report struct {
+	StartTime time.Time
+	Attempts  []Attempt
+	// FirstSuccessTime is when the first attempt that connected and ran the query finished.
+	FirstSuccessTime time.Time
+	// FirstWriteTime is when the first attempt that wrote with the configured consistency finished.
+	FirstWriteTime time.Time
+}
+
+func (r *Report) add(attempt Attempt) {
+	r.Attempts = append(r.Attempts, attempt)
+
+	endTime := attempt.StartTime.Add(attempt.Duration)
+	reachedQuery := attempt.Stage == WriteStage || (attempt.Stage == QueryStage && attempt.Succeeded())
+	if reachedQuery && r.FirstSuccessTime.IsZero() {
+		r.FirstSuccessTime = endTime
+	}
+
+	if attempt.Stage == WriteStage && attempt.Succeeded() && r.FirstWriteTime.IsZero() {
+		r.FirstWriteTime = endTime
+	}
+}
+
+// ErrorHistogram returns the number of failed attempts by their error class.
+func (r *Report) ErrorHistogram() map[ErrorClass]int {
+	histogram := map[ErrorClass]int{}
+	for _, a := range r.Attempts {
+		if !a.Succeeded() {
+			histogram[a.ErrorClass]++
+		}
+	}
+
+	return histogram
+}
@@ -0,0 +1,57 @@
+package probe
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestReport(t *testing.T) {
+	t.Parallel()
+
+	startTime := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	attempts := []Attempt{
+		{StartTime: startTime, Duration: time.Second, Stage: ConnectStage, Err: errors.New("refused"), ErrorClass: DialErrorClass},
+		{StartTime: startTime.Add(2 * time.Second), Duration: time.Second, Stage: ConnectStage, Err: errors.New("refused"), ErrorClass: DialErrorClass},
+		{StartTime: startTime.Add(4 * time.Second), Duration: time.Second, Stage: WriteStage, Err: errors.New("unavailable"), ErrorClass: UnavailableErrorClass},
+		{StartTime: startTime.Add(6 * time.Second), Duration: time.Second, Stage: WriteStage},
+	}
+
+	report := &Report{StartTime: startTime}
+	for _, a := range attempts {
+		report.add(a)
+	}
+
+	if expected := startTime.Add(5 * time.Second); !report.FirstSuccessTime.Equal(expected) {
+		t.Errorf("expected first success at %v, got %v", expected, report.FirstSuccessTime)
+	}
+
+	if expected := startTime.Add(7 * time.Second); !report.FirstWriteTime.Equal(expected) {
+		t.Errorf("expected first write at %v, got %v", expected, report.FirstWriteTime)
+	}
+
+	expectedHistogram := map[ErrorClass]int{
+		DialErrorClass:        2,
+		UnavailableErrorClass: 1,
+	}
+	if got := report.ErrorHistogram(); !reflect.DeepEqual(got, expectedHistogram) {
+		t.Errorf("expected error histogram %v, got %v", expectedHistogram, got)
+	}
+}
+
+func TestOptionsValidate(t *testing.T) {
+	t.Parallel()
+
+	options := Options{Keyspace: DefaultKeyspace, Interval: DefaultInterval}
+	err := options.Validate()
+	if err != nil {
+		t.Errorf("expected valid options, got %v", err)
+	}
+
+	options = Options{}
+	err = options.Validate()
+	if err == nil {
+		t.Errorf("expected an error for empty options")
+	}
+}
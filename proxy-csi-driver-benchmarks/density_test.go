@@ -0,0 +1,250 @@
+package proxy_csi_driver_benchmarks_test
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	g "github.com/onsi/ginkgo/v2"
+	o "github.com/onsi/gomega"
+	"github.com/pausing-clusters-thesis/benchmarks/results"
+	"github.com/pausing-clusters-thesis/benchmarks/stats"
+	"github.com/pausing-clusters-thesis/benchmarks/sweep"
+	"github.com/pausing-clusters-thesis/benchmarks/utils"
+	socontrollerhelpers "github.com/scylladb/scylla-operator/pkg/controllerhelpers"
+	"github.com/scylladb/scylla-operator/test/e2e/framework"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+const (
+	podLabel = "pod"
+
+	// densitySummaryScenarioSuffix is appended to the scenario of the per-pod records to name the scenario of their
+	// summary, so that the summary isn't pooled with the per-pod samples.
+	densitySummaryScenarioSuffix = "-summary"
+	podReadyOperation            = "pod-ready"
+)
+
+type densityMember struct {
+	pod        *corev1.Pod
+	proxyPVC   *corev1.PersistentVolumeClaim
+	backendPVC *corev1.PersistentVolumeClaim
+
+	stopTime time.Time
+	err      error
+}
+
+// bindTogether annotates the proxy PVCs of all members at once, and records when each pod became ready.
+// It returns the time the annotations were issued.
+func bindTogether(ctx context.Context, client corev1client.CoreV1Interface, members []*densityMember) time.Time {
+	var annotateWG, readyWG sync.WaitGroup
+	startCh := make(chan struct{})
+	for _, m := range members {
+		// The pod is replaced once it's ready, so it's captured before either goroutine starts.
+		pod := m.pod
+
+		annotateWG.Add(1)
+		go func() {
+			defer annotateWG.Done()
+			defer g.GinkgoRecover()
+
+			<-startCh
+			annotateProxyPVCWithBackendPVCRef(ctx, client, pod, m.proxyPVC, m.backendPVC.GetName())
+		}()
+
+		readyWG.Add(1)
+		go func() {
+			defer readyWG.Done()
+
+			<-startCh
+			m.pod, m.err = socontrollerhelpers.WaitForPodState(ctx, client.Pods(pod.GetNamespace()), pod.GetName(), socontrollerhelpers.WaitForStateOptions{}, isPodReady)
+			m.stopTime = time.Now()
+		}()
+	}
+
+	startTime := time.Now()
+	close(startCh)
+	annotateWG.Wait()
+	readyWG.Wait()
+
+	return startTime
+}
+
+// summarizePodReadiness summarizes the times from the start of the measurement until each pod became ready.
+func summarizePodReadiness(elapsedTimesMs []float64) results.LatencySummary {
+	s := stats.Summarize(elapsedTimesMs)
+	sorted := slices.Sorted(slices.Values(elapsedTimesMs))
+
+	return results.LatencySummary{
+		Count:  int64(s.N),
+		MinMs:  s.Min,
+		MeanMs: s.Mean,
+		P50Ms:  s.P50,
+		P90Ms:  s.P90,
+		P99Ms:  s.P99,
+		P999Ms: stats.Percentile(sorted, 99.9),
+		MaxMs:  s.Max,
+	}
+}
+
+var _ = g.Describe("measure time to readiness at node density", func() {
+	f := framework.NewFramework("benchmark")
+
+	type densityEntry struct {
+		volume          sweep.Volume
+		density         int
+		resultsFileName string
+	}
+
+	var densityEntries []g.TableEntry
+	for _, v := range volumes {
+		for _, density := range podDensities {
+			densityEntries = append(densityEntries, g.Entry(fmt.Sprintf("with %d pods with a %s backend volume on one node", density, v), &densityEntry{
+				volume:          v,
+				density:         density,
+				resultsFileName: fmt.Sprintf("density-%d%s", density, v.ResultsSuffix()),
+			}))
+		}
+	}
+
+	g.DescribeTable("pre-warming many pods with proxy volumes on one node", func(ctx g.SpecContext, e *densityEntry) {
+		resultSink, err := results.NewSink(resultSinkOptions, e.resultsFileName)
+		o.Expect(err).NotTo(o.HaveOccurred())
+		g.DeferCleanup(resultSink.Close)
+
+		c := f.Cluster(0)
+		ns, nsClient := c.CreateUserNamespace(ctx)
+
+		backendImmediateStorageClass, err := utils.GetImmediateStorageClassForCSIDriverWithFSType(backendCSIDriverName, e.volume.FSType)
+		o.Expect(err).NotTo(o.HaveOccurred())
+
+		framework.By("Creating immediate StorageClass for backend CSI driver")
+		backendImmediateStorageClass, err = c.KubeAdminClient().StorageV1().StorageClasses().Create(ctx, backendImmediateStorageClass, metav1.CreateOptions{})
+		o.Expect(err).NotTo(o.HaveOccurred())
+
+		g.DeferCleanup(func(ctx g.SpecContext, backendImmediateStorageClass *storagev1.StorageClass) {
+			framework.By("Deleting immediate StorageClass")
+			err := c.KubeAdminClient().StorageV1().StorageClasses().Delete(ctx, backendImmediateStorageClass.GetName(), metav1.DeleteOptions{})
+			o.Expect(err).NotTo(o.HaveOccurred())
+		}, backendImmediateStorageClass)
+
+		framework.By("Creating backend PVC 0")
+		backendPVC, backendPV := createBoundBackendPVC(ctx, nsClient.KubeClient(), c.KubeAdminClient(), ns.GetName(), backendImmediateStorageClass.GetName(), e.volume)
+		members := []*densityMember{{backendPVC: backendPVC}}
+
+		if e.density > 1 {
+			// All pods have to fit onto the node of the first one, so their backend volumes are restricted to the
+			// topology of the first backend volume.
+			pinnedStorageClass := createPinnedStorageClass(ctx, c.KubeAdminClient(), backendImmediateStorageClass, backendPV)
+
+			for i := 1; i < e.density; i++ {
+				framework.By("Creating backend PVC %d", i)
+				backendPVC, _ := createBoundBackendPVC(ctx, nsClient.KubeClient(), c.KubeAdminClient(), ns.GetName(), pinnedStorageClass.GetName(), e.volume)
+				members = append(members, &densityMember{backendPVC: backendPVC})
+			}
+		}
+
+		var nodeName string
+		for i, m := range members {
+			m.proxyPVC = createProxyPVC(ctx, nsClient.KubeClient(), ns.GetName(), e.volume)
+
+			pod := getPodTemplate(ns.GetName(), m.proxyPVC.GetName(), e.volume.Mode)
+			pod.Name = fmt.Sprintf("test-%d", i)
+			pod.Spec.Containers[0].Command = []string{
+				"bin/sh",
+				"-euEo",
+				"pipefail",
+				"-c",
+				strings.TrimSpace(fmt.Sprintf(`
+trap 'kill $( jobs -p ); exit 0' TERM
+
+while true; do
+	%s && break
+done
+
+sleep infinity &
+wait $!
+`, getDataAccessCommand(e.volume.Mode, getDataPath(e.volume.Mode, 0)))),
+			}
+
+			// Set Pod's NodeSelector to match backend PV's NodeSelector.
+			if backendPV.Spec.NodeAffinity != nil && backendPV.Spec.NodeAffinity.Required != nil {
+				pod.Spec.Affinity = &corev1.Affinity{
+					NodeAffinity: &corev1.NodeAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: backendPV.Spec.NodeAffinity.Required.DeepCopy(),
+					},
+				}
+			}
+
+			// The remaining pods follow the first one onto its node.
+			if len(nodeName) != 0 {
+				pod.Spec.NodeSelector = map[string]string{
+					corev1.LabelHostname: nodeName,
+				}
+			}
+
+			framework.By("Creating pre-warmed Pod %q", pod.Name)
+			pod, err = nsClient.KubeClient().CoreV1().Pods(ns.GetName()).Create(ctx, pod, metav1.CreateOptions{})
+			o.Expect(err).NotTo(o.HaveOccurred())
+
+			framework.By("Waiting for Pod %q to be running", pod.Name)
+			podRunningCtx, podRunningCtxCancel := context.WithTimeout(ctx, prewarmTimeout)
+			m.pod, err = socontrollerhelpers.WaitForPodState(podRunningCtx, nsClient.KubeClient().CoreV1().Pods(ns.GetName()), pod.GetName(), socontrollerhelpers.WaitForStateOptions{}, isPodRunning)
+			podRunningCtxCancel()
+			o.Expect(err).NotTo(o.HaveOccurred())
+
+			if len(nodeName) == 0 {
+				nodeName = m.pod.Spec.NodeName
+				framework.Infof("Packing %d pods onto node %q", e.density, nodeName)
+			}
+		}
+
+		startTime := bindTogether(ctx, nsClient.KubeClient().CoreV1(), members)
+
+		var stopTime time.Time
+		elapsedTimesMs := make([]float64, 0, len(members))
+		for _, m := range members {
+			o.Expect(m.err).NotTo(o.HaveOccurred())
+
+			res := results.NewRecord(runMetadata, e.resultsFileName, startTime, m.stopTime)
+			res.Labels = map[string]string{
+				podLabel: m.pod.GetName(),
+			}
+			res.VolumeSizeBytes = e.volume.Size.Value()
+			res.FSType = e.volume.FSType
+			res.VolumeAccessMode = string(e.volume.AccessMode)
+			res.VolumeMode = string(e.volume.Mode)
+			framework.Infof("Pod %q became ready after %dms.", m.pod.GetName(), res.ElapsedTimeMs)
+
+			err = resultSink.Write(ctx, res)
+			o.Expect(err).NotTo(o.HaveOccurred())
+
+			if m.stopTime.After(stopTime) {
+				stopTime = m.stopTime
+			}
+			elapsedTimesMs = append(elapsedTimesMs, float64(res.ElapsedTimeMs))
+		}
+
+		// The summary ends when the last pod became ready.
+		summary := results.NewRecord(runMetadata, e.resultsFileName+densitySummaryScenarioSuffix, startTime, stopTime)
+		summary.VolumeSizeBytes = e.volume.Size.Value()
+		summary.FSType = e.volume.FSType
+		summary.VolumeAccessMode = string(e.volume.AccessMode)
+		summary.VolumeMode = string(e.volume.Mode)
+		summary.Latencies = map[string]results.LatencySummary{
+			podReadyOperation: summarizePodReadiness(elapsedTimesMs),
+		}
+		framework.Infof("All %d pods became ready after %dms, p50 %.0fms, p90 %.0fms.", len(members), summary.ElapsedTimeMs, summary.Latencies[podReadyOperation].P50Ms, summary.Latencies[podReadyOperation].P90Ms)
+
+		err = resultSink.Write(ctx, summary)
+		o.Expect(err).NotTo(o.HaveOccurred())
+	},
+		densityEntries,
+	)
+})
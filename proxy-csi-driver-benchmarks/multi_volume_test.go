@@ -0,0 +1,388 @@
+package proxy_csi_driver_benchmarks_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	g "github.com/onsi/ginkgo/v2"
+	o "github.com/onsi/gomega"
+	"github.com/pausing-clusters-thesis/benchmarks/results"
+	"github.com/pausing-clusters-thesis/benchmarks/sweep"
+	"github.com/pausing-clusters-thesis/benchmarks/timeline"
+	"github.com/pausing-clusters-thesis/benchmarks/utils"
+	proxycsinaming "github.com/pausing-clusters-thesis/proxy-csi-driver/pkg/naming"
+	socontrollerhelpers "github.com/scylladb/scylla-operator/pkg/controllerhelpers"
+	"github.com/scylladb/scylla-operator/test/e2e/framework"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+)
+
+type bindingOrder string
+
+const (
+	// concurrentBindingOrder issues the bindings of all proxy volumes at once.
+	concurrentBindingOrder bindingOrder = "concurrent"
+	// sequentialBindingOrder issues the binding of each proxy volume once the previous one is mounted.
+	sequentialBindingOrder bindingOrder = "sequential"
+)
+
+var _ = g.Describe("measure time to readiness with multiple volumes", func() {
+	f := framework.NewFramework("benchmark")
+
+	type multiVolumeEntry struct {
+		volume          sweep.Volume
+		volumeCount     int
+		bindingOrder    bindingOrder
+		resultsFileName string
+	}
+
+	var multiVolumeEntries []g.TableEntry
+	for _, v := range volumes {
+		for _, volumeCount := range volumeCounts {
+			for _, bo := range []bindingOrder{concurrentBindingOrder, sequentialBindingOrder} {
+				multiVolumeEntries = append(multiVolumeEntries, g.Entry(fmt.Sprintf("with %d %s backend volumes bound in %s order", volumeCount, v, bo), &multiVolumeEntry{
+					volume:          v,
+					volumeCount:     volumeCount,
+					bindingOrder:    bo,
+					resultsFileName: fmt.Sprintf("multi-volume-%d-%s%s", volumeCount, bo, v.ResultsSuffix()),
+				}))
+			}
+		}
+	}
+
+	g.DescribeTable("pre-warming pod with multiple proxy volumes", func(ctx g.SpecContext, e *multiVolumeEntry) {
+		resultSink, err := results.NewSink(resultSinkOptions, e.resultsFileName)
+		o.Expect(err).NotTo(o.HaveOccurred())
+		g.DeferCleanup(resultSink.Close)
+
+		c := f.Cluster(0)
+		ns, nsClient := c.CreateUserNamespace(ctx)
+
+		backendImmediateStorageClass, err := utils.GetImmediateStorageClassForCSIDriverWithFSType(backendCSIDriverName, e.volume.FSType)
+		o.Expect(err).NotTo(o.HaveOccurred())
+
+		framework.By("Creating immediate StorageClass for backend CSI driver")
+		backendImmediateStorageClass, err = c.KubeAdminClient().StorageV1().StorageClasses().Create(ctx, backendImmediateStorageClass, metav1.CreateOptions{})
+		o.Expect(err).NotTo(o.HaveOccurred())
+
+		g.DeferCleanup(func(ctx g.SpecContext, backendImmediateStorageClass *storagev1.StorageClass) {
+			framework.By("Deleting immediate StorageClass")
+			err := c.KubeAdminClient().StorageV1().StorageClasses().Delete(ctx, backendImmediateStorageClass.GetName(), metav1.DeleteOptions{})
+			o.Expect(err).NotTo(o.HaveOccurred())
+		}, backendImmediateStorageClass)
+
+		framework.By("Creating backend PVC 0")
+		backendPVC, backendPV := createBoundBackendPVC(ctx, nsClient.KubeClient(), c.KubeAdminClient(), ns.GetName(), backendImmediateStorageClass.GetName(), e.volume)
+		backendPVCs := []*corev1.PersistentVolumeClaim{backendPVC}
+
+		if e.volumeCount > 1 {
+			// Backend volumes provisioned with immediate binding could end up on different nodes or zones, which would
+			// leave the pod unschedulable, so the remaining ones are restricted to the topology of the first one.
+			pinnedStorageClass := createPinnedStorageClass(ctx, c.KubeAdminClient(), backendImmediateStorageClass, backendPV)
+
+			for i := 1; i < e.volumeCount; i++ {
+				framework.By("Creating backend PVC %d", i)
+				backendPVC, _ := createBoundBackendPVC(ctx, nsClient.KubeClient(), c.KubeAdminClient(), ns.GetName(), pinnedStorageClass.GetName(), e.volume)
+				backendPVCs = append(backendPVCs, backendPVC)
+			}
+		}
+
+		framework.By("Creating proxy PVCs")
+		proxyPVCs := make([]*corev1.PersistentVolumeClaim, 0, e.volumeCount)
+		proxyPVCNames := make([]string, 0, e.volumeCount)
+		for range e.volumeCount {
+			proxyPVC := createProxyPVC(ctx, nsClient.KubeClient(), ns.GetName(), e.volume)
+
+			proxyPVCs = append(proxyPVCs, proxyPVC)
+			proxyPVCNames = append(proxyPVCNames, proxyPVC.GetName())
+		}
+
+		pod := getMultiVolumePodTemplate(ns.GetName(), proxyPVCNames, e.volume.Mode)
+
+		// Set Pod's NodeSelector to match backend PV's NodeSelector.
+		if backendPV.Spec.NodeAffinity != nil && backendPV.Spec.NodeAffinity.Required != nil {
+			pod.Spec.Affinity = &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: backendPV.Spec.NodeAffinity.Required.DeepCopy(),
+				},
+			}
+		}
+
+		framework.By("Creating pre-warmed Pod")
+		pod, err = nsClient.KubeClient().CoreV1().Pods(ns.GetName()).Create(ctx, pod, metav1.CreateOptions{})
+		o.Expect(err).NotTo(o.HaveOccurred())
+
+		framework.By("Waiting for Pod to be running")
+		podRunningCtx, podRunningCtxCancel := context.WithTimeout(ctx, prewarmTimeout)
+		defer podRunningCtxCancel()
+		pod, err = socontrollerhelpers.WaitForPodState(podRunningCtx, nsClient.KubeClient().CoreV1().Pods(ns.GetName()), pod.GetName(), socontrollerhelpers.WaitForStateOptions{}, isPodRunning)
+		o.Expect(err).NotTo(o.HaveOccurred())
+
+		startTime := time.Now()
+		stopwatch := timeline.NewStopwatch(startTime)
+
+		switch e.bindingOrder {
+		case concurrentBindingOrder:
+			var wg sync.WaitGroup
+			for i := range proxyPVCs {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					defer g.GinkgoRecover()
+
+					annotateProxyPVCWithBackendPVCRef(ctx, nsClient.KubeClient().CoreV1(), pod, proxyPVCs[i], backendPVCs[i].GetName())
+				}()
+			}
+			wg.Wait()
+
+		case sequentialBindingOrder:
+			for i := range proxyPVCs {
+				annotateProxyPVCWithBackendPVCRef(ctx, nsClient.KubeClient().CoreV1(), pod, proxyPVCs[i], backendPVCs[i].GetName())
+
+				volumeName, err := getPodVolumeName(pod, proxyPVCs[i].GetName())
+				o.Expect(err).NotTo(o.HaveOccurred())
+
+				framework.By("Waiting for proxy volume %q to be mounted", volumeName)
+				_, err = socontrollerhelpers.WaitForPodState(ctx, f.KubeAdminClient().CoreV1().Pods(ns.GetName()), pod.GetName(), socontrollerhelpers.WaitForStateOptions{}, isProxyVolumeMounted(volumeName))
+				o.Expect(err).NotTo(o.HaveOccurred())
+				stopwatch.Lap(fmt.Sprintf("volume_%d_mounted", i))
+			}
+
+		default:
+			g.Fail(fmt.Sprintf("unsupported binding order %q", e.bindingOrder))
+		}
+		stopwatch.Lap("bindings_issued")
+
+		pod, err = socontrollerhelpers.WaitForPodState(ctx, f.KubeAdminClient().CoreV1().Pods(ns.GetName()), pod.GetName(), socontrollerhelpers.WaitForStateOptions{}, isPodReady)
+		stopTime := stopwatch.Lap("pod_ready")
+		o.Expect(err).NotTo(o.HaveOccurred())
+
+		podReadyCondition, err := getPodCondition(pod, corev1.PodReady)
+		o.Expect(err).NotTo(o.HaveOccurred())
+
+		elapsedTime := stopTime.Sub(startTime)
+		framework.Infof("Time elapsed: %v, stop time %v, pod ready condition timestamp %v, phases: %v", elapsedTime, stopTime, podReadyCondition.LastTransitionTime, stopwatch.Phases())
+
+		res := results.NewRecord(runMetadata, e.resultsFileName, startTime, stopTime)
+		res.VolumeSizeBytes = e.volume.Size.Value()
+		res.FSType = e.volume.FSType
+		res.VolumeAccessMode = string(e.volume.AccessMode)
+		res.VolumeMode = string(e.volume.Mode)
+		res.VolumeCount = e.volumeCount
+		res.Phases = stopwatch.Phases()
+		err = resultSink.Write(ctx, res)
+		o.Expect(err).NotTo(o.HaveOccurred())
+	},
+		multiVolumeEntries,
+	)
+})
+
+// getPodVolumeName returns the name of the volume of the pod that uses the PVC.
+func getPodVolumeName(pod *corev1.Pod, pvcName string) (string, error) {
+	for _, v := range pod.Spec.Volumes {
+		if v.PersistentVolumeClaim != nil && v.PersistentVolumeClaim.ClaimName == pvcName {
+			return v.Name, nil
+		}
+	}
+
+	return "", fmt.Errorf("pod %q has no volume using PVC %q", pod.GetName(), pvcName)
+}
+
+// isProxyVolumeMounted returns a condition that is met once the proxy CSI driver marks the volume of the pod as mounted.
+func isProxyVolumeMounted(volumeName string) func(*corev1.Pod) (bool, error) {
+	return func(pod *corev1.Pod) (bool, error) {
+		return pod.GetAnnotations()[fmt.Sprintf(proxycsinaming.DelayedStorageMountedAnnotationFormat, volumeName)] == proxycsinaming.DelayedStorageMountedAnnotationTrue, nil
+	}
+}
+
+// createBoundBackendPVC creates a backend PVC and waits for it to be bound.
+func createBoundBackendPVC(ctx context.Context, client kubernetes.Interface, adminClient kubernetes.Interface, namespace string, storageClassName string, v sweep.Volume) (*corev1.PersistentVolumeClaim, *corev1.PersistentVolume) {
+	backendPVC := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "backend-pvc-",
+			Namespace:    namespace,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{
+				v.AccessMode,
+			},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: v.Size,
+				},
+			},
+			StorageClassName: ptr.To(storageClassName),
+			VolumeMode:       ptr.To(v.Mode),
+		},
+	}
+
+	backendPVC, err := client.CoreV1().PersistentVolumeClaims(namespace).Create(ctx, backendPVC, metav1.CreateOptions{})
+	o.Expect(err).NotTo(o.HaveOccurred())
+
+	framework.By("Waiting for backend PVC %q to be bound", backendPVC.GetName())
+	backendPVCBindingCtx, backendPVCBindingCtxCancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer backendPVCBindingCtxCancel()
+	backendPVC, err = waitForPersistentVolumeClaimState(backendPVCBindingCtx, adminClient.CoreV1().PersistentVolumeClaims(namespace), backendPVC.GetName(), socontrollerhelpers.WaitForStateOptions{}, isPersistentVolumeClaimBound)
+	o.Expect(err).NotTo(o.HaveOccurred())
+	o.Expect(backendPVC.Spec.VolumeName).NotTo(o.BeEmpty())
+
+	backendPV, err := adminClient.CoreV1().PersistentVolumes().Get(ctx, backendPVC.Spec.VolumeName, metav1.GetOptions{})
+	o.Expect(err).NotTo(o.HaveOccurred())
+
+	return backendPVC, backendPV
+}
+
+// createProxyPVC creates a proxy PVC for a backend volume. It isn't bound until it's annotated with a backend PVC.
+func createProxyPVC(ctx context.Context, client kubernetes.Interface, namespace string, v sweep.Volume) *corev1.PersistentVolumeClaim {
+	proxyPVC := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "proxy-pvc-",
+			Namespace:    namespace,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{
+				v.AccessMode,
+			},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					// Doesn't matter, only a proxy directory is created.
+					corev1.ResourceStorage: resource.MustParse("1Mi"),
+				},
+			},
+			StorageClassName: ptr.To(proxyStorageClassName),
+			VolumeMode:       ptr.To(v.Mode),
+		},
+	}
+
+	proxyPVC, err := client.CoreV1().PersistentVolumeClaims(namespace).Create(ctx, proxyPVC, metav1.CreateOptions{})
+	o.Expect(err).NotTo(o.HaveOccurred())
+
+	return proxyPVC
+}
+
+// createPinnedStorageClass creates a copy of the StorageClass restricted to the topology of the PV. If the PV has
+// no topology, the StorageClass is returned as is.
+func createPinnedStorageClass(ctx context.Context, adminClient kubernetes.Interface, sc *storagev1.StorageClass, pv *corev1.PersistentVolume) *storagev1.StorageClass {
+	allowedTopologies := getAllowedTopologies(pv)
+	if len(allowedTopologies) == 0 {
+		return sc
+	}
+
+	framework.By("Creating immediate StorageClass restricted to the topology of backend PV %q", pv.GetName())
+	pinnedStorageClass := &storagev1.StorageClass{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: sc.GetName() + "-pinned-",
+		},
+		Provisioner:       sc.Provisioner,
+		Parameters:        sc.Parameters,
+		ReclaimPolicy:     sc.ReclaimPolicy,
+		VolumeBindingMode: sc.VolumeBindingMode,
+		AllowedTopologies: allowedTopologies,
+	}
+	pinnedStorageClass, err := adminClient.StorageV1().StorageClasses().Create(ctx, pinnedStorageClass, metav1.CreateOptions{})
+	o.Expect(err).NotTo(o.HaveOccurred())
+
+	g.DeferCleanup(func(ctx g.SpecContext, pinnedStorageClass *storagev1.StorageClass) {
+		framework.By("Deleting restricted immediate StorageClass")
+		err := adminClient.StorageV1().StorageClasses().Delete(ctx, pinnedStorageClass.GetName(), metav1.DeleteOptions{})
+		o.Expect(err).NotTo(o.HaveOccurred())
+	}, pinnedStorageClass)
+
+	return pinnedStorageClass
+}
+
+// getAllowedTopologies translates the node affinity of the PV into StorageClass topologies.
+// Only requirements with the In operator can be expressed as topologies, others are skipped.
+func getAllowedTopologies(pv *corev1.PersistentVolume) []corev1.TopologySelectorTerm {
+	if pv.Spec.NodeAffinity == nil || pv.Spec.NodeAffinity.Required == nil {
+		return nil
+	}
+
+	var terms []corev1.TopologySelectorTerm
+	for _, nodeSelectorTerm := range pv.Spec.NodeAffinity.Required.NodeSelectorTerms {
+		var term corev1.TopologySelectorTerm
+		for _, req := range nodeSelectorTerm.MatchExpressions {
+			if req.Operator != corev1.NodeSelectorOpIn {
+				continue
+			}
+
+			term.MatchLabelExpressions = append(term.MatchLabelExpressions, corev1.TopologySelectorLabelRequirement{
+				Key:    req.Key,
+				Values: req.Values,
+			})
+		}
+
+		if len(term.MatchLabelExpressions) != 0 {
+			terms = append(terms, term)
+		}
+	}
+
+	return terms
+}
+
+// getMultiVolumePodTemplate returns a pod template with a data volume for each of the PVCs. The container busy-waits
+// for the volumes one after another and the pod is only ready once all of them were accessed.
+func getMultiVolumePodTemplate(namespace string, dataPVCNames []string, volumeMode corev1.PersistentVolumeMode) *corev1.Pod {
+	pod := getPodTemplate(namespace, dataPVCNames[0], volumeMode)
+	container := &pod.Spec.Containers[0]
+
+	for i, dataPVCName := range dataPVCNames[1:] {
+		name := fmt.Sprintf("data-%d", i+1)
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: dataPVCName,
+					ReadOnly:  false,
+				},
+			},
+		})
+
+		if volumeMode == corev1.PersistentVolumeBlock {
+			container.VolumeDevices = append(container.VolumeDevices, corev1.VolumeDevice{
+				Name:       name,
+				DevicePath: getDataPath(volumeMode, i+1),
+			})
+			continue
+		}
+
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:             name,
+			ReadOnly:         false,
+			MountPath:        getDataPath(volumeMode, i+1),
+			MountPropagation: ptr.To(corev1.MountPropagationHostToContainer),
+		})
+	}
+
+	var sb strings.Builder
+	sb.WriteString("trap 'kill $( jobs -p ); exit 0' TERM\n\n")
+	readinessCommands := make([]string, 0, len(dataPVCNames))
+	for i := range dataPVCNames {
+		dataPath := getDataPath(volumeMode, i)
+		fmt.Fprintf(&sb, "while true; do\n\t%s && break\ndone\n", getDataAccessCommand(volumeMode, dataPath))
+		readinessCommands = append(readinessCommands, getDataReadinessCommand(volumeMode, dataPath))
+	}
+	sb.WriteString("\nsleep infinity &\nwait $!")
+
+	container.Command = []string{
+		"bin/sh",
+		"-euEo",
+		"pipefail",
+		"-c",
+		sb.String(),
+	}
+	container.ReadinessProbe.Exec.Command = []string{
+		"/bin/sh",
+		"-c",
+		strings.Join(readinessCommands, " && "),
+	}
+
+	return pod
+}
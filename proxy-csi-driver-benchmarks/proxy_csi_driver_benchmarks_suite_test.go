@@ -6,14 +6,16 @@ import (
 	"flag"
 	"fmt"
 	"os"
-	"path"
 	"strings"
 	"testing"
 	"time"
 
 	g "github.com/onsi/ginkgo/v2"
 	o "github.com/onsi/gomega"
+	"github.com/pausing-clusters-thesis/benchmarks/kubeletstats"
 	"github.com/pausing-clusters-thesis/benchmarks/naming"
+	"github.com/pausing-clusters-thesis/benchmarks/results"
+	"github.com/pausing-clusters-thesis/benchmarks/sweep"
 	"github.com/pausing-clusters-thesis/benchmarks/utils"
 	proxycsinaming "github.com/pausing-clusters-thesis/proxy-csi-driver/pkg/naming"
 	socontrollerhelpers "github.com/scylladb/scylla-operator/pkg/controllerhelpers"
@@ -23,21 +25,18 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	storagev1 "k8s.io/api/storage/v1"
-	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/apimachinery/pkg/util/json"
 	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/utils/ptr"
 )
 
-type result struct {
-	ElapsedTimeMs int64 `json:"elapsed_time_ms"`
-}
-
 const (
 	prewarmTimeout = 1 * time.Minute
+
+	busyboxImage        = "docker.io/library/busybox:latest@sha256:498a000f370d8c37927118ed80afe8adc38d1edcbfc071627d17b25c88efcab0"
+	proxyCSIDriverImage = "docker.io/rzetelskik/proxy-csi-driver:latest@sha256:7f22416a68afc8b16abd88d3cc5f9bfb399e83310946f118e9e7933a060276fa"
 )
 
 var (
@@ -49,6 +48,24 @@ var (
 	backendCSIDriverName  string
 	imagePullPolicy       corev1.PullPolicy
 	destDir               string
+	resultFormatsString   = results.FormatJSONLines
+	resultCollectorURL    string
+	sweepConfigPath       string
+	volumeSizesString     = sweep.DefaultVolumeSize
+	fsTypesString         = sweep.DefaultFSType
+	accessModesString     = string(sweep.DefaultVolumeAccessMode)
+	volumeModesString     = string(sweep.DefaultVolumeMode)
+	volumeCountsString    = "2"
+	podDensitiesString    = "1,10"
+
+	resourceSamplingWindow   = 1 * time.Minute
+	resourceSamplingInterval = 10 * time.Second
+
+	runMetadata       *results.Metadata
+	resultSinkOptions results.SinkOptions
+	volumes           []sweep.Volume
+	volumeCounts      []int
+	podDensities      []int
 )
 
 var supportedImagePullPolicyStrings = []string{
@@ -67,6 +84,17 @@ func init() {
 	flag.StringVar(&backendCSIDriverName, "backend-csi-driver-name", backendCSIDriverName, fmt.Sprintf("The name of the backend CSI driver to test. Supported drvier names are: %v.", supportedBackendCSIDriverNames))
 	flag.StringVar(&imagePullPolicyString, "image-pull-policy", imagePullPolicyString, fmt.Sprintf("ImagePullPolicy to use for containers. Supported policies are: %v. In case of PullNever, the image has to pre-pulled.", supportedImagePullPolicyStrings))
 	flag.StringVar(&destDir, "dest-dir", destDir, "Destination directory in which results should be saved.")
+	flag.StringVar(&resultFormatsString, "result-formats", resultFormatsString, fmt.Sprintf("Comma-separated list of formats in which results should be saved in dest-dir. Supported formats are: %v.", results.SupportedFormats))
+	flag.StringVar(&resultCollectorURL, "result-collector-url", resultCollectorURL, "URL of a collector to which results should be additionally sent in POST requests (optional).")
+	flag.StringVar(&sweepConfigPath, "sweep-config", sweepConfigPath, "Path to a YAML file with the backend volumes to sweep through. Volume parameters in the file take precedence over the corresponding flags (optional).")
+	flag.StringVar(&volumeSizesString, "volume-sizes", volumeSizesString, "Comma-separated list of sizes of backend volumes, e.g. 100Mi,10Gi.")
+	flag.StringVar(&fsTypesString, "fs-types", fsTypesString, fmt.Sprintf("Comma-separated list of filesystem types of backend volumes. Supported types are: %v.", sweep.SupportedFSTypes))
+	flag.StringVar(&accessModesString, "access-modes", accessModesString, fmt.Sprintf("Comma-separated list of access modes of backend and proxy volumes. Supported access modes are: %v.", sweep.SupportedVolumeAccessModes))
+	flag.StringVar(&volumeModesString, "volume-modes", volumeModesString, fmt.Sprintf("Comma-separated list of volume modes of backend and proxy volumes. Filesystem type doesn't apply to block volumes. Supported volume modes are: %v.", sweep.SupportedVolumeModes))
+	flag.StringVar(&volumeCountsString, "volume-counts", volumeCountsString, "Comma-separated list of numbers of proxy volumes of a pod in the multi-volume scenarios.")
+	flag.StringVar(&podDensitiesString, "pod-densities", podDensitiesString, "Comma-separated list of numbers of pre-warmed pods packed onto a single node in the node density scenarios. Densities are bounded by the attachable volume limit of the node.")
+	flag.DurationVar(&resourceSamplingWindow, "resource-sampling-window", resourceSamplingWindow, "How long CPU and memory usage of idle pre-warmed pods is sampled before binding their volumes. Zero disables sampling.")
+	flag.DurationVar(&resourceSamplingInterval, "resource-sampling-interval", resourceSamplingInterval, "The interval between samples of CPU and memory usage of idle pre-warmed pods. Kubelet only refreshes the stats every few seconds.")
 }
 
 func TestProxyCsiDriverBenchmarks(t *testing.T) {
@@ -122,6 +150,107 @@ func Validate() error {
 		errs = append(errs, fmt.Errorf("dest-dir can't be empty"))
 	}
 
+	resultFormats, err := results.ParseFormats(resultFormatsString)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("invalid result-formats: %w", err))
+	} else {
+		resultSinkOptions = results.SinkOptions{
+			Formats:      resultFormats,
+			DestDir:      destDir,
+			CollectorURL: resultCollectorURL,
+		}
+
+		err = resultSinkOptions.Validate()
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	volumeSizes, err := sweep.ParseVolumeSizes(volumeSizesString)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("invalid volume-sizes: %w", err))
+	}
+
+	fsTypes, err := sweep.ParseFSTypes(fsTypesString)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("invalid fs-types: %w", err))
+	}
+
+	accessModes, err := sweep.ParseVolumeAccessModes(accessModesString)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("invalid access-modes: %w", err))
+	}
+
+	volumeModes, err := sweep.ParseVolumeModes(volumeModesString)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("invalid volume-modes: %w", err))
+	}
+
+	if resourceSamplingWindow < 0 {
+		errs = append(errs, fmt.Errorf("resource-sampling-window must not be negative, got %v", resourceSamplingWindow))
+	}
+
+	if resourceSamplingInterval <= 0 {
+		errs = append(errs, fmt.Errorf("resource-sampling-interval must be greater than zero, got %v", resourceSamplingInterval))
+	} else if resourceSamplingWindow > 0 && resourceSamplingInterval >= resourceSamplingWindow {
+		errs = append(errs, fmt.Errorf("resource-sampling-interval %v must be less than resource-sampling-window %v", resourceSamplingInterval, resourceSamplingWindow))
+	}
+
+	volumeCounts, err = sweep.ParseVolumeCounts(volumeCountsString)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("invalid volume-counts: %w", err))
+	}
+
+	podDensities, err = sweep.ParsePodDensities(podDensitiesString)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("invalid pod-densities: %w", err))
+	}
+
+	if len(sweepConfigPath) > 0 {
+		sweepConfig, err := sweep.ReadConfigFile(sweepConfigPath)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			if sweepConfig.VolumeSizes != nil {
+				volumeSizes = sweepConfig.VolumeSizes
+			}
+
+			if sweepConfig.FSTypes != nil {
+				fsTypes = sweepConfig.FSTypes
+			}
+
+			if sweepConfig.VolumeAccessModes != nil {
+				accessModes = sweepConfig.VolumeAccessModes
+			}
+
+			if sweepConfig.VolumeModes != nil {
+				volumeModes = sweepConfig.VolumeModes
+			}
+
+			if sweepConfig.VolumeCounts != nil {
+				volumeCounts = sweepConfig.VolumeCounts
+			}
+
+			if sweepConfig.PodDensities != nil {
+				podDensities = sweepConfig.PodDensities
+			}
+		}
+	}
+
+	if backendCSIDriverName == naming.LocalCSIDriverName {
+		for _, fsType := range fsTypes {
+			if fsType != sweep.DefaultFSType {
+				errs = append(errs, fmt.Errorf("backend CSI driver %q only supports %q filesystem type, got %q", backendCSIDriverName, sweep.DefaultFSType, fsType))
+			}
+		}
+
+		if slices.ContainsItem(volumeModes, corev1.PersistentVolumeBlock) {
+			errs = append(errs, fmt.Errorf("backend CSI driver %q doesn't support %q volume mode", backendCSIDriverName, corev1.PersistentVolumeBlock))
+		}
+	}
+
+	volumes = sweep.VolumeMatrix(volumeSizes, fsTypes, accessModes, volumeModes)
+
 	return errors.Join(errs...)
 }
 
@@ -135,23 +264,32 @@ func Complete() error {
 
 	imagePullPolicy = corev1.PullPolicy(imagePullPolicyString)
 
+	runMetadata, err = results.NewMetadata(flag.CommandLine, g.GinkgoRandomSeed(), []string{busyboxImage, proxyCSIDriverImage})
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
 var _ = g.Describe("measure time to readiness", func() {
 	f := framework.NewFramework("benchmark")
 
-	g.It("creating pod from scratch (baseline)", func(ctx g.SpecContext) {
-		const resultsFileName = "baseline"
-		resultsFilePath := path.Join(destDir, resultsFileName)
-		resultsFile, err := os.OpenFile(resultsFilePath, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0644)
+	var volumeEntries []g.TableEntry
+	for _, v := range volumes {
+		volumeEntries = append(volumeEntries, g.Entry(fmt.Sprintf("with a %s backend volume", v), v))
+	}
+
+	g.DescribeTable("creating pod from scratch (baseline)", func(ctx g.SpecContext, v sweep.Volume) {
+		resultsFileName := "baseline" + v.ResultsSuffix()
+		resultSink, err := results.NewSink(resultSinkOptions, resultsFileName)
 		o.Expect(err).NotTo(o.HaveOccurred())
-		g.DeferCleanup(resultsFile.Close)
+		g.DeferCleanup(resultSink.Close)
 
 		c := f.Cluster(0)
 		ns, nsClient := c.CreateUserNamespace(ctx)
 
-		backendImmediateStorageClass, err := utils.GetImmediateStorageClassForCSIDriver(backendCSIDriverName)
+		backendImmediateStorageClass, err := utils.GetImmediateStorageClassForCSIDriverWithFSType(backendCSIDriverName, v.FSType)
 		o.Expect(err).NotTo(o.HaveOccurred())
 
 		framework.By("Creating immediate StorageClass for backend CSI driver")
@@ -165,51 +303,22 @@ var _ = g.Describe("measure time to readiness", func() {
 		}, backendImmediateStorageClass)
 
 		framework.By("Creating backend PVC")
-		backendPVC := &corev1.PersistentVolumeClaim{
-			ObjectMeta: metav1.ObjectMeta{
-				GenerateName: "backend-pvc",
-			},
-			Spec: corev1.PersistentVolumeClaimSpec{
-				AccessModes: []corev1.PersistentVolumeAccessMode{
-					corev1.ReadWriteOnce,
-				},
-				Resources: corev1.VolumeResourceRequirements{
-					Requests: corev1.ResourceList{
-						corev1.ResourceStorage: resource.MustParse("100Mi"),
-					},
-				},
-				StorageClassName: ptr.To(backendImmediateStorageClass.GetName()),
-				VolumeMode:       ptr.To(corev1.PersistentVolumeFilesystem),
-			},
-		}
+		backendPVC, backendPV := createBoundBackendPVC(ctx, nsClient.KubeClient(), c.KubeAdminClient(), ns.GetName(), backendImmediateStorageClass.GetName(), v)
 
-		backendPVC, err = nsClient.KubeClient().CoreV1().PersistentVolumeClaims(ns.GetName()).Create(ctx, backendPVC, metav1.CreateOptions{})
-		o.Expect(err).NotTo(o.HaveOccurred())
-
-		framework.By("Waiting for backend PVC to be bound")
-		backendPVCBindingCtx, backendPVCBindingCtxCancel := context.WithTimeout(ctx, 2*time.Minute)
-		defer backendPVCBindingCtxCancel()
-		backendPVC, err = waitForPersistentVolumeClaimState(backendPVCBindingCtx, f.KubeAdminClient().CoreV1().PersistentVolumeClaims(ns.GetName()), backendPVC.GetName(), socontrollerhelpers.WaitForStateOptions{}, isPersistentVolumeClaimBound)
-		o.Expect(err).NotTo(o.HaveOccurred())
-		o.Expect(backendPVC.Spec.VolumeName).NotTo(o.BeEmpty())
-
-		backendPV, err := c.KubeAdminClient().CoreV1().PersistentVolumes().Get(ctx, backendPVC.Spec.VolumeName, metav1.GetOptions{})
-		o.Expect(err).NotTo(o.HaveOccurred())
-
-		pod := getPodTemplate(ns.GetName(), backendPVC.GetName())
+		pod := getPodTemplate(ns.GetName(), backendPVC.GetName(), v.Mode)
 		pod.Spec.Containers[0].Command = []string{
 			"bin/sh",
 			"-euEo",
 			"pipefail",
 			"-c",
-			strings.TrimSpace(`
+			strings.TrimSpace(fmt.Sprintf(`
 trap 'kill $( jobs -p ); exit 0' TERM
 
-touch /data/test
+%s
 
 sleep infinity &
 wait $!
-`),
+`, getDataAccessCommand(v.Mode, getDataPath(v.Mode, 0)))),
 		}
 
 		// Set Pod's NodeSelector to match backend PV's NodeSelector.
@@ -236,23 +345,27 @@ wait $!
 		elapsedTime := stopTime.Sub(startTime)
 		framework.Infof("Time elapsed: %v, stop time %v, pod ready condition timestamp %v", elapsedTime, stopTime, podReadyCondition.LastTransitionTime)
 
-		encoder := json.NewEncoder(resultsFile)
-		res := result{ElapsedTimeMs: elapsedTime.Milliseconds()}
-		err = encoder.Encode(res)
+		res := results.NewRecord(runMetadata, resultsFileName, startTime, stopTime)
+		res.VolumeSizeBytes = v.Size.Value()
+		res.FSType = v.FSType
+		res.VolumeAccessMode = string(v.AccessMode)
+		res.VolumeMode = string(v.Mode)
+		err = resultSink.Write(ctx, res)
 		o.Expect(err).NotTo(o.HaveOccurred())
-	})
+	},
+		volumeEntries,
+	)
 
-	g.It("pre-warming pod with proxy volume", func(ctx g.SpecContext) {
-		const resultsFileName = "busywait"
-		resultsFilePath := path.Join(destDir, resultsFileName)
-		resultsFile, err := os.OpenFile(resultsFilePath, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0644)
+	g.DescribeTable("pre-warming pod with proxy volume", func(ctx g.SpecContext, v sweep.Volume) {
+		resultsFileName := "busywait" + v.ResultsSuffix()
+		resultSink, err := results.NewSink(resultSinkOptions, resultsFileName)
 		o.Expect(err).NotTo(o.HaveOccurred())
-		g.DeferCleanup(resultsFile.Close)
+		g.DeferCleanup(resultSink.Close)
 
 		c := f.Cluster(0)
 		ns, nsClient := c.CreateUserNamespace(ctx)
 
-		backendImmediateStorageClass, err := utils.GetImmediateStorageClassForCSIDriver(backendCSIDriverName)
+		backendImmediateStorageClass, err := utils.GetImmediateStorageClassForCSIDriverWithFSType(backendCSIDriverName, v.FSType)
 		o.Expect(err).NotTo(o.HaveOccurred())
 
 		framework.By("Creating immediate StorageClass for backend CSI driver")
@@ -266,76 +379,27 @@ wait $!
 		}, backendImmediateStorageClass)
 
 		framework.By("Creating backend PVC")
-		backendPVC := &corev1.PersistentVolumeClaim{
-			ObjectMeta: metav1.ObjectMeta{
-				GenerateName: "backend-pvc",
-			},
-			Spec: corev1.PersistentVolumeClaimSpec{
-				AccessModes: []corev1.PersistentVolumeAccessMode{
-					corev1.ReadWriteOnce,
-				},
-				Resources: corev1.VolumeResourceRequirements{
-					Requests: corev1.ResourceList{
-						corev1.ResourceStorage: resource.MustParse("100Mi"),
-					},
-				},
-				StorageClassName: ptr.To(backendImmediateStorageClass.GetName()),
-				VolumeMode:       ptr.To(corev1.PersistentVolumeFilesystem),
-			},
-		}
-
-		backendPVC, err = nsClient.KubeClient().CoreV1().PersistentVolumeClaims(ns.GetName()).Create(ctx, backendPVC, metav1.CreateOptions{})
-		o.Expect(err).NotTo(o.HaveOccurred())
-
-		framework.By("Waiting for backend PVC to be bound")
-		backendPVCBindingCtx, backendPVCBindingCtxCancel := context.WithTimeout(ctx, 2*time.Minute)
-		defer backendPVCBindingCtxCancel()
-		backendPVC, err = waitForPersistentVolumeClaimState(backendPVCBindingCtx, f.KubeAdminClient().CoreV1().PersistentVolumeClaims(ns.GetName()), backendPVC.GetName(), socontrollerhelpers.WaitForStateOptions{}, isPersistentVolumeClaimBound)
-		o.Expect(err).NotTo(o.HaveOccurred())
-		o.Expect(backendPVC.Spec.VolumeName).NotTo(o.BeEmpty())
-
-		backendPV, err := c.KubeAdminClient().CoreV1().PersistentVolumes().Get(ctx, backendPVC.Spec.VolumeName, metav1.GetOptions{})
-		o.Expect(err).NotTo(o.HaveOccurred())
+		backendPVC, backendPV := createBoundBackendPVC(ctx, nsClient.KubeClient(), c.KubeAdminClient(), ns.GetName(), backendImmediateStorageClass.GetName(), v)
 
 		framework.By("Creating proxy PVC")
-		proxyPVC := &corev1.PersistentVolumeClaim{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: "proxy-pvc",
-			},
-			Spec: corev1.PersistentVolumeClaimSpec{
-				AccessModes: []corev1.PersistentVolumeAccessMode{
-					corev1.ReadWriteOnce,
-				},
-				Resources: corev1.VolumeResourceRequirements{
-					Requests: corev1.ResourceList{
-						// Doesn't matter, only a proxy directory is created.
-						corev1.ResourceStorage: resource.MustParse("1Mi"),
-					},
-				},
-				StorageClassName: ptr.To(proxyStorageClassName),
-				VolumeMode:       ptr.To(corev1.PersistentVolumeFilesystem),
-			},
-		}
-
-		proxyPVC, err = nsClient.KubeClient().CoreV1().PersistentVolumeClaims(ns.GetName()).Create(ctx, proxyPVC, metav1.CreateOptions{})
-		o.Expect(err).NotTo(o.HaveOccurred())
+		proxyPVC := createProxyPVC(ctx, nsClient.KubeClient(), ns.GetName(), v)
 
-		pod := getPodTemplate(ns.GetName(), proxyPVC.GetName())
+		pod := getPodTemplate(ns.GetName(), proxyPVC.GetName(), v.Mode)
 		pod.Spec.Containers[0].Command = []string{
 			"bin/sh",
 			"-euEo",
 			"pipefail",
 			"-c",
-			strings.TrimSpace(`
+			strings.TrimSpace(fmt.Sprintf(`
 trap 'kill $( jobs -p ); exit 0' TERM
 
 while true; do
-	touch /data/test && break
+	%s && break
 done
 
 sleep infinity &
 wait $!
-`),
+`, getDataAccessCommand(v.Mode, getDataPath(v.Mode, 0)))),
 		}
 
 		// Set Pod's NodeSelector to match backend PV's NodeSelector.
@@ -357,6 +421,11 @@ wait $!
 		pod, err = socontrollerhelpers.WaitForPodState(podRunningCtx, nsClient.KubeClient().CoreV1().Pods(ns.GetName()), pod.GetName(), socontrollerhelpers.WaitForStateOptions{}, isPodRunning)
 		o.Expect(err).NotTo(o.HaveOccurred())
 
+		var resourceUsage *results.ResourceUsage
+		if resourceSamplingWindow > 0 {
+			resourceUsage = sampleIdlePodResourceUsage(ctx, c.KubeAdminClient().CoreV1().RESTClient(), pod)
+		}
+
 		startTime := time.Now()
 
 		annotateProxyPVCWithBackendPVCRef(ctx, nsClient.KubeClient().CoreV1(), pod, proxyPVC, backendPVC.GetName())
@@ -371,23 +440,28 @@ wait $!
 		elapsedTime := stopTime.Sub(startTime)
 		framework.Infof("Time elapsed: %v, stop time %v, pod ready condition timestamp %v", elapsedTime, stopTime, podReadyCondition.LastTransitionTime)
 
-		encoder := json.NewEncoder(resultsFile)
-		res := result{ElapsedTimeMs: elapsedTime.Milliseconds()}
-		err = encoder.Encode(res)
+		res := results.NewRecord(runMetadata, resultsFileName, startTime, stopTime)
+		res.VolumeSizeBytes = v.Size.Value()
+		res.FSType = v.FSType
+		res.VolumeAccessMode = string(v.AccessMode)
+		res.VolumeMode = string(v.Mode)
+		res.Resources = resourceUsage
+		err = resultSink.Write(ctx, res)
 		o.Expect(err).NotTo(o.HaveOccurred())
-	})
+	},
+		volumeEntries,
+	)
 
-	g.It("pre-warms pod with proxy volume and suggested wait mechanism", func(ctx g.SpecContext) {
-		const resultsFileName = "sidecar"
-		resultsFilePath := path.Join(destDir, resultsFileName)
-		resultsFile, err := os.OpenFile(resultsFilePath, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0644)
+	g.DescribeTable("pre-warms pod with proxy volume and suggested wait mechanism", func(ctx g.SpecContext, v sweep.Volume) {
+		resultsFileName := "sidecar" + v.ResultsSuffix()
+		resultSink, err := results.NewSink(resultSinkOptions, resultsFileName)
 		o.Expect(err).NotTo(o.HaveOccurred())
-		g.DeferCleanup(resultsFile.Close)
+		g.DeferCleanup(resultSink.Close)
 
 		c := f.Cluster(0)
 		ns, nsClient := c.CreateUserNamespace(ctx)
 
-		backendImmediateStorageClass, err := utils.GetImmediateStorageClassForCSIDriver(backendCSIDriverName)
+		backendImmediateStorageClass, err := utils.GetImmediateStorageClassForCSIDriverWithFSType(backendCSIDriverName, v.FSType)
 		o.Expect(err).NotTo(o.HaveOccurred())
 
 		framework.By("Creating immediate StorageClass for backend CSI driver")
@@ -443,80 +517,29 @@ wait $!
 		o.Expect(err).NotTo(o.HaveOccurred())
 
 		framework.By("Creating backend PVC")
-		backendPVC := &corev1.PersistentVolumeClaim{
-			ObjectMeta: metav1.ObjectMeta{
-				GenerateName: "backend-pvc",
-				Namespace:    ns.GetName(),
-			},
-			Spec: corev1.PersistentVolumeClaimSpec{
-				AccessModes: []corev1.PersistentVolumeAccessMode{
-					corev1.ReadWriteOnce,
-				},
-				Resources: corev1.VolumeResourceRequirements{
-					Requests: corev1.ResourceList{
-						corev1.ResourceStorage: resource.MustParse("100Mi"),
-					},
-				},
-				StorageClassName: ptr.To(backendImmediateStorageClass.GetName()),
-				VolumeMode:       ptr.To(corev1.PersistentVolumeFilesystem),
-			},
-		}
-
-		backendPVC, err = nsClient.KubeClient().CoreV1().PersistentVolumeClaims(ns.GetName()).Create(ctx, backendPVC, metav1.CreateOptions{})
-		o.Expect(err).NotTo(o.HaveOccurred())
-
-		framework.By("Waiting for backend PVC to be bound")
-		backendPVCBindingCtx, backendPVCBindingCtxCancel := context.WithTimeout(ctx, 2*time.Minute)
-		defer backendPVCBindingCtxCancel()
-		backendPVC, err = waitForPersistentVolumeClaimState(backendPVCBindingCtx, f.KubeAdminClient().CoreV1().PersistentVolumeClaims(ns.GetName()), backendPVC.GetName(), socontrollerhelpers.WaitForStateOptions{}, isPersistentVolumeClaimBound)
-		o.Expect(err).NotTo(o.HaveOccurred())
-		o.Expect(backendPVC.Spec.VolumeName).NotTo(o.BeEmpty())
-
-		backendPV, err := c.KubeAdminClient().CoreV1().PersistentVolumes().Get(ctx, backendPVC.Spec.VolumeName, metav1.GetOptions{})
-		o.Expect(err).NotTo(o.HaveOccurred())
+		backendPVC, backendPV := createBoundBackendPVC(ctx, nsClient.KubeClient(), c.KubeAdminClient(), ns.GetName(), backendImmediateStorageClass.GetName(), v)
 
 		framework.By("Creating proxy PVC")
-		proxyPVC := &corev1.PersistentVolumeClaim{
-			ObjectMeta: metav1.ObjectMeta{
-				GenerateName: "proxy-pvc-",
-				Namespace:    ns.GetName(),
-			},
-			Spec: corev1.PersistentVolumeClaimSpec{
-				AccessModes: []corev1.PersistentVolumeAccessMode{
-					corev1.ReadWriteOnce,
-				},
-				Resources: corev1.VolumeResourceRequirements{
-					Requests: corev1.ResourceList{
-						// Doesn't matter, only a proxy directory is created.
-						corev1.ResourceStorage: resource.MustParse("1Mi"),
-					},
-				},
-				StorageClassName: ptr.To(proxyStorageClassName),
-				VolumeMode:       ptr.To(corev1.PersistentVolumeFilesystem),
-			},
-		}
-
-		proxyPVC, err = nsClient.KubeClient().CoreV1().PersistentVolumeClaims(ns.GetName()).Create(ctx, proxyPVC, metav1.CreateOptions{})
-		o.Expect(err).NotTo(o.HaveOccurred())
+		proxyPVC := createProxyPVC(ctx, nsClient.KubeClient(), ns.GetName(), v)
 
-		pod := getPodTemplate(ns.GetName(), proxyPVC.GetName())
+		pod := getPodTemplate(ns.GetName(), proxyPVC.GetName(), v.Mode)
 		pod.Spec.ServiceAccountName = podSA.Name
 		pod.Spec.Containers[0].Command = []string{
 			"bin/sh",
 			"-euEo",
 			"pipefail",
 			"-c",
-			strings.TrimSpace(`
+			strings.TrimSpace(fmt.Sprintf(`
 trap 'kill $( jobs -p ); exit 0' TERM
 
 while true; do
 	test -f "/var/lib/shared/backend-volume-mounting.done" && break
 done
-touch /data/test
+%s
 
 sleep infinity &
 wait $!
-`),
+`, getDataAccessCommand(v.Mode, getDataPath(v.Mode, 0)))),
 		}
 
 		// Set Pod's NodeSelector to match backend PV's NodeSelector.
@@ -536,7 +559,7 @@ wait $!
 
 		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{
 			Name:  "wait",
-			Image: "docker.io/rzetelskik/proxy-csi-driver:latest@sha256:7f22416a68afc8b16abd88d3cc5f9bfb399e83310946f118e9e7933a060276fa",
+			Image: proxyCSIDriverImage,
 			Args: []string{
 				"wait",
 				"--pod-name=$(POD_NAME)",
@@ -578,6 +601,11 @@ wait $!
 		pod, err = socontrollerhelpers.WaitForPodState(podRunningCtx, nsClient.KubeClient().CoreV1().Pods(ns.GetName()), pod.GetName(), socontrollerhelpers.WaitForStateOptions{}, isPodRunning)
 		o.Expect(err).NotTo(o.HaveOccurred())
 
+		var resourceUsage *results.ResourceUsage
+		if resourceSamplingWindow > 0 {
+			resourceUsage = sampleIdlePodResourceUsage(ctx, c.KubeAdminClient().CoreV1().RESTClient(), pod)
+		}
+
 		startTime := time.Now()
 
 		annotateProxyPVCWithBackendPVCRef(ctx, nsClient.KubeClient().CoreV1(), pod, proxyPVC, backendPVC.GetName())
@@ -592,13 +620,33 @@ wait $!
 		elapsedTime := stopTime.Sub(startTime)
 		framework.Infof("Time elapsed: %v, stop time %v, pod ready condition timestamp %v", elapsedTime, stopTime, podReadyCondition.LastTransitionTime)
 
-		encoder := json.NewEncoder(resultsFile)
-		res := result{ElapsedTimeMs: elapsedTime.Milliseconds()}
-		err = encoder.Encode(res)
-		o.Expect(err).NotTo(o.HaveOccurred())
-	})
+		res := results.NewRecord(runMetadata, resultsFileName, startTime, stopTime)
+		res.VolumeSizeBytes = v.Size.Value()
+		res.FSType = v.FSType
+		res.VolumeAccessMode = string(v.AccessMode)
+		res.VolumeMode = string(v.Mode)
+		res.Resources = resourceUsage
+		err = resultSink.Write(ctx, res)
+		o.Expect(err).NotTo(o.HaveOccurred())
+	},
+		volumeEntries,
+	)
 })
 
+// sampleIdlePodResourceUsage samples CPU and memory usage of the pod, which has to be idle for the whole window.
+func sampleIdlePodResourceUsage(ctx context.Context, client restclient.Interface, pod *corev1.Pod) *results.ResourceUsage {
+	framework.By("Sampling resource usage of idle Pod %q for %v", pod.GetName(), resourceSamplingWindow)
+	samples, err := kubeletstats.SamplePod(ctx, client, pod.Spec.NodeName, pod.GetNamespace(), pod.GetName(), resourceSamplingWindow, resourceSamplingInterval)
+	o.Expect(err).NotTo(o.HaveOccurred())
+
+	resourceUsage, err := kubeletstats.Usage(samples)
+	o.Expect(err).NotTo(o.HaveOccurred())
+
+	framework.Infof("Idle Pod %q used %.1f CPU millicores and %d bytes of memory on average over %dms", pod.GetName(), resourceUsage.CPUMillicores, resourceUsage.MemoryWorkingSetBytes, resourceUsage.WindowMs)
+
+	return resourceUsage
+}
+
 func waitForPersistentVolumeClaimState(ctx context.Context, client corev1client.PersistentVolumeClaimInterface, name string, options socontrollerhelpers.WaitForStateOptions, condition func(*corev1.PersistentVolumeClaim) (bool, error), additionalConditions ...func(*corev1.PersistentVolumeClaim) (bool, error)) (*corev1.PersistentVolumeClaim, error) {
 	return socontrollerhelpers.WaitForObjectState[*corev1.PersistentVolumeClaim, *corev1.PersistentVolumeClaimList](ctx, client, name, options, condition, additionalConditions...)
 }
@@ -607,8 +655,47 @@ func isPersistentVolumeClaimBound(claim *corev1.PersistentVolumeClaim) (bool, er
 	return len(claim.Spec.VolumeName) != 0, nil
 }
 
-func getPodTemplate(namespace string, dataPVCName string) *corev1.Pod {
-	return &corev1.Pod{
+const (
+	dataMountPath  = "/data"
+	dataDevicePath = "/dev/data"
+)
+
+// getDataPath returns the path at which the i-th data volume is available in the container.
+func getDataPath(volumeMode corev1.PersistentVolumeMode, i int) string {
+	path := dataMountPath
+	if volumeMode == corev1.PersistentVolumeBlock {
+		path = dataDevicePath
+	}
+
+	if i == 0 {
+		return path
+	}
+
+	return fmt.Sprintf("%s-%d", path, i)
+}
+
+// getDataAccessCommand returns a shell command accessing the data volume, which succeeds once the backend volume
+// is available.
+func getDataAccessCommand(volumeMode corev1.PersistentVolumeMode, dataPath string) string {
+	if volumeMode == corev1.PersistentVolumeBlock {
+		return fmt.Sprintf("dd if=%s of=/dev/null bs=4096 count=1", dataPath)
+	}
+
+	return fmt.Sprintf("touch %s/test", dataPath)
+}
+
+// getDataReadinessCommand returns a shell command that succeeds once the data volume was accessed by the container.
+// Reading a block device has no side effects, so block volumes are ready as soon as they can be accessed.
+func getDataReadinessCommand(volumeMode corev1.PersistentVolumeMode, dataPath string) string {
+	if volumeMode == corev1.PersistentVolumeBlock {
+		return getDataAccessCommand(volumeMode, dataPath)
+	}
+
+	return fmt.Sprintf("test -f %s/test", dataPath)
+}
+
+func getPodTemplate(namespace string, dataPVCName string, volumeMode corev1.PersistentVolumeMode) *corev1.Pod {
+	pod := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "test",
 			Namespace: namespace,
@@ -628,7 +715,7 @@ func getPodTemplate(namespace string, dataPVCName string) *corev1.Pod {
 			Containers: []corev1.Container{
 				{
 					Name:  "sleep",
-					Image: "docker.io/library/busybox:latest@sha256:498a000f370d8c37927118ed80afe8adc38d1edcbfc071627d17b25c88efcab0",
+					Image: busyboxImage,
 					// Exit 1 to enforce setting the command explicitly.
 					Command: []string{
 						"exit",
@@ -638,7 +725,7 @@ func getPodTemplate(namespace string, dataPVCName string) *corev1.Pod {
 						{
 							Name:             "data",
 							ReadOnly:         false,
-							MountPath:        "/data",
+							MountPath:        dataMountPath,
 							MountPropagation: ptr.To(corev1.MountPropagationHostToContainer),
 						},
 					},
@@ -647,9 +734,9 @@ func getPodTemplate(namespace string, dataPVCName string) *corev1.Pod {
 						ProbeHandler: corev1.ProbeHandler{
 							Exec: &corev1.ExecAction{
 								Command: []string{
-									"test",
-									"-f",
-									"/data/test",
+									"/bin/sh",
+									"-c",
+									getDataReadinessCommand(volumeMode, getDataPath(volumeMode, 0)),
 								},
 							},
 						},
@@ -695,6 +782,25 @@ func getPodTemplate(namespace string, dataPVCName string) *corev1.Pod {
 			},
 		},
 	}
+
+	if volumeMode == corev1.PersistentVolumeBlock {
+		container := &pod.Spec.Containers[0]
+		container.VolumeMounts = nil
+		container.VolumeDevices = []corev1.VolumeDevice{
+			{
+				Name:       "data",
+				DevicePath: dataDevicePath,
+			},
+		}
+		// FSGroup isn't applied to block devices, which are only accessible to root.
+		pod.Spec.SecurityContext = &corev1.PodSecurityContext{
+			RunAsUser:    ptr.To[int64](0),
+			RunAsGroup:   ptr.To[int64](0),
+			RunAsNonRoot: ptr.To(false),
+		}
+	}
+
+	return pod
 }
 
 func getPodCondition(pod *corev1.Pod, conditionType corev1.PodConditionType) (*corev1.PodCondition, error) {
@@ -0,0 +1,57 @@
+package results
+
+import (
+	"maps"
+	"slices"
+	"time"
+)
+
+// Availability is the availability of a cluster as perceived by a client probing it during the measurement.
+type Availability struct {
+	Attempts             int   `json:"attempts"`
+	TimeToFirstSuccessMs int64 `json:"time_to_first_success_ms"`
+	// TimeToFirstWriteMs is the time until the first write with the consistency level of the scenario succeeded.
+	TimeToFirstWriteMs int64 `json:"time_to_first_write_ms"`
+	// Errors is the number of failed attempts by the class of their error.
+	Errors map[string]int `json:"errors,omitempty"`
+}
+
+func NewAvailability(startTime time.Time, attempts int, firstSuccessTime, firstWriteTime time.Time, errors map[string]int) *Availability {
+	return &Availability{
+		Attempts:             attempts,
+		TimeToFirstSuccessMs: firstSuccessTime.Sub(startTime).Milliseconds(),
+		TimeToFirstWriteMs:   firstWriteTime.Sub(startTime).Milliseconds(),
+		Errors:               errors,
+	}
+}
+
+const (
+	AvailabilityAttemptsMetric           = "attempts"
+	AvailabilityTimeToFirstSuccessMetric = "time_to_first_success_ms"
+	AvailabilityTimeToFirstWriteMetric   = "time_to_first_write_ms"
+
+	availabilityMetricPrefix      = "availability/"
+	availabilityErrorMetricPrefix = availabilityMetricPrefix + "errors/"
+)
+
+func AvailabilityMetric(metric string) string {
+	return availabilityMetricPrefix + metric
+}
+
+func AvailabilityErrorMetric(errorClass string) string {
+	return availabilityErrorMetricPrefix + errorClass
+}
+
+func (a *Availability) metrics() []Metric {
+	metrics := []Metric{
+		{Name: AvailabilityMetric(AvailabilityAttemptsMetric), Value: float64(a.Attempts), Unit: UnitCount},
+		{Name: AvailabilityMetric(AvailabilityTimeToFirstSuccessMetric), Value: float64(a.TimeToFirstSuccessMs), Unit: UnitMilliseconds},
+		{Name: AvailabilityMetric(AvailabilityTimeToFirstWriteMetric), Value: float64(a.TimeToFirstWriteMs), Unit: UnitMilliseconds},
+	}
+
+	for _, errorClass := range slices.Sorted(maps.Keys(a.Errors)) {
+		metrics = append(metrics, Metric{Name: AvailabilityErrorMetric(errorClass), Value: float64(a.Errors[errorClass]), Unit: UnitCount})
+	}
+
+	return metrics
+}
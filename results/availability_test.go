@@ -0,0 +1,36 @@
+package results
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecordAvailabilityMetrics(t *testing.T) {
+	t.Parallel()
+
+	startTime := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	record := NewRecord(&Metadata{RunID: "run"}, "cold", startTime, startTime.Add(20*time.Second))
+	record.Availability = NewAvailability(startTime, 12, startTime.Add(15*time.Second), startTime.Add(18*time.Second), map[string]int{
+		"unavailable": 1,
+		"dial":        10,
+	})
+
+	expected := []Metric{
+		{Name: "availability/attempts", Value: 12, Unit: UnitCount},
+		{Name: "availability/time_to_first_success_ms", Value: 15000, Unit: UnitMilliseconds},
+		{Name: "availability/time_to_first_write_ms", Value: 18000, Unit: UnitMilliseconds},
+		{Name: "availability/errors/dial", Value: 10, Unit: UnitCount},
+		{Name: "availability/errors/unavailable", Value: 1, Unit: UnitCount},
+	}
+	var got []Metric
+	for _, m := range record.Metrics() {
+		if strings.HasPrefix(m.Name, availabilityMetricPrefix) {
+			got = append(got, m)
+		}
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected availability metrics %#v, got %#v", expected, got)
+	}
+}
@@ -0,0 +1,67 @@
+package results
+
+// HostConnection is the timeline of the first connection to a single host made by the client during the measurement.
+// Offsets are relative to the start of the measurement.
+type HostConnection struct {
+	// HostID is empty for initial contact points, which are connected to before host IDs are known.
+	HostID          string `json:"host_id,omitempty"`
+	Address         string `json:"address"`
+	ConnectAttempts int    `json:"connect_attempts"`
+	FailedConnects  int    `json:"failed_connects,omitempty"`
+	// ConnectOffsetMs is the time until the first connection to the host was established.
+	ConnectOffsetMs int64 `json:"connect_offset_ms"`
+	ConnectMs       int64 `json:"connect_ms"`
+	// TCPMs is the time it took to open the TCP connection to the ingress.
+	TCPMs int64 `json:"tcp_ms"`
+	// TLSMs is the time it took to complete the TLS handshake, including SNI routing at the ingress.
+	TLSMs              int64 `json:"tls_ms"`
+	FirstQueryOffsetMs int64 `json:"first_query_offset_ms,omitempty"`
+	FirstQueryMs       int64 `json:"first_query_ms,omitempty"`
+}
+
+const (
+	ConnectionsFailedConnectsMetric       = "failed_connects"
+	ConnectionsLastConnectOffsetMetric    = "last_connect_offset_ms"
+	ConnectionsMaxConnectMetric           = "max_connect_ms"
+	ConnectionsMaxTCPMetric               = "max_tcp_ms"
+	ConnectionsMaxTLSMetric               = "max_tls_ms"
+	ConnectionsLastFirstQueryOffsetMetric = "last_first_query_offset_ms"
+
+	connectionsMetricPrefix = "connections/"
+)
+
+func ConnectionsMetric(metric string) string {
+	return connectionsMetricPrefix + metric
+}
+
+// connectionsMetrics aggregates the connections over all hosts, as host IDs change between runs.
+func connectionsMetrics(connections []HostConnection) []Metric {
+	if len(connections) == 0 {
+		return nil
+	}
+
+	var failedConnects int
+	var lastConnectOffsetMs, maxConnectMs, maxTCPMs, maxTLSMs, lastFirstQueryOffsetMs int64
+	for _, hc := range connections {
+		failedConnects += hc.FailedConnects
+		lastConnectOffsetMs = max(lastConnectOffsetMs, hc.ConnectOffsetMs)
+		maxConnectMs = max(maxConnectMs, hc.ConnectMs)
+		maxTCPMs = max(maxTCPMs, hc.TCPMs)
+		maxTLSMs = max(maxTLSMs, hc.TLSMs)
+		lastFirstQueryOffsetMs = max(lastFirstQueryOffsetMs, hc.FirstQueryOffsetMs)
+	}
+
+	metrics := []Metric{
+		{Name: ConnectionsMetric(ConnectionsFailedConnectsMetric), Value: float64(failedConnects), Unit: UnitCount},
+		{Name: ConnectionsMetric(ConnectionsLastConnectOffsetMetric), Value: float64(lastConnectOffsetMs), Unit: UnitMilliseconds},
+		{Name: ConnectionsMetric(ConnectionsMaxConnectMetric), Value: float64(maxConnectMs), Unit: UnitMilliseconds},
+		{Name: ConnectionsMetric(ConnectionsMaxTCPMetric), Value: float64(maxTCPMs), Unit: UnitMilliseconds},
+		{Name: ConnectionsMetric(ConnectionsMaxTLSMetric), Value: float64(maxTLSMs), Unit: UnitMilliseconds},
+	}
+
+	if lastFirstQueryOffsetMs != 0 {
+		metrics = append(metrics, Metric{Name: ConnectionsMetric(ConnectionsLastFirstQueryOffsetMetric), Value: float64(lastFirstQueryOffsetMs), Unit: UnitMilliseconds})
+	}
+
+	return metrics
+}
@@ -0,0 +1,49 @@
+package results
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRecordConnectionsMetrics(t *testing.T) {
+	t.Parallel()
+
+	startTime := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	record := NewRecord(&Metadata{RunID: "run"}, "cold", startTime, startTime.Add(20*time.Second))
+	record.Connections = []HostConnection{
+		{
+			Address:         "10.0.0.1:443",
+			ConnectAttempts: 3,
+			FailedConnects:  2,
+			ConnectOffsetMs: 15000,
+			ConnectMs:       120,
+			TCPMs:           2,
+			TLSMs:           80,
+		},
+		{
+			HostID:             "a",
+			Address:            "10.0.0.1:443",
+			ConnectAttempts:    1,
+			ConnectOffsetMs:    19000,
+			ConnectMs:          150,
+			TCPMs:              3,
+			TLSMs:              70,
+			FirstQueryOffsetMs: 19500,
+			FirstQueryMs:       40,
+		},
+	}
+
+	expected := []Metric{
+		{Name: "elapsed_time_ms", Value: 20000, Unit: UnitMilliseconds},
+		{Name: "connections/failed_connects", Value: 2, Unit: UnitCount},
+		{Name: "connections/last_connect_offset_ms", Value: 19000, Unit: UnitMilliseconds},
+		{Name: "connections/max_connect_ms", Value: 150, Unit: UnitMilliseconds},
+		{Name: "connections/max_tcp_ms", Value: 3, Unit: UnitMilliseconds},
+		{Name: "connections/max_tls_ms", Value: 80, Unit: UnitMilliseconds},
+		{Name: "connections/last_first_query_offset_ms", Value: 19500, Unit: UnitMilliseconds},
+	}
+	if got := record.Metrics(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected metrics %#v, got %#v", expected, got)
+	}
+}
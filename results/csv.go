@@ -0,0 +1,100 @@
+package results
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var csvHeader = []string{
+	"schema_version",
+	"run_id",
+	"scenario",
+	"start_time",
+	"end_time",
+	"metric",
+	"value",
+}
+
+// CSVFileSink appends records to a CSV file in a long format, with a row for every metric of a record.
+type CSVFileSink struct {
+	lock sync.Mutex
+	file *os.File
+}
+
+var _ ResultSink = &CSVFileSink{}
+
+func NewCSVFileSink(filePath string) (*CSVFileSink, error) {
+	f, err := os.OpenFile(filePath, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("can't open results file %q: %w", filePath, err)
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("can't stat results file %q: %w", filePath, err)
+	}
+
+	if fi.Size() == 0 {
+		w := csv.NewWriter(f)
+		err = w.Write(csvHeader)
+		if err == nil {
+			w.Flush()
+			err = w.Error()
+		}
+		if err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("can't write header to %q: %w", filePath, err)
+		}
+	}
+
+	return &CSVFileSink{
+		file: f,
+	}, nil
+}
+
+func (s *CSVFileSink) Write(_ context.Context, record *Record) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	w := csv.NewWriter(s.file)
+	for _, m := range record.Metrics() {
+		err := w.Write([]string{
+			strconv.Itoa(record.SchemaVersion),
+			record.RunID,
+			record.Scenario,
+			formatCSVTime(record.StartTime),
+			formatCSVTime(record.EndTime),
+			m.Name,
+			strconv.FormatFloat(m.Value, 'f', -1, 64),
+		})
+		if err != nil {
+			return fmt.Errorf("can't write record to %q: %w", s.file.Name(), err)
+		}
+	}
+
+	w.Flush()
+	err := w.Error()
+	if err != nil {
+		return fmt.Errorf("can't write record to %q: %w", s.file.Name(), err)
+	}
+
+	return nil
+}
+
+func (s *CSVFileSink) Close() error {
+	return s.file.Close()
+}
+
+func formatCSVTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(time.RFC3339Nano)
+}
@@ -0,0 +1,65 @@
+package results
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	httpSinkTimeout = 30 * time.Second
+)
+
+// HTTPSink sends every record as a JSON object in a POST request to a collector.
+type HTTPSink struct {
+	url    string
+	client *http.Client
+}
+
+var _ ResultSink = &HTTPSink{}
+
+func NewHTTPSink(url string) *HTTPSink {
+	return &HTTPSink{
+		url: url,
+		client: &http.Client{
+			Timeout: httpSinkTimeout,
+		},
+	}
+}
+
+func (s *HTTPSink) Write(ctx context.Context, record *Record) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("can't encode record: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("can't create request to %q: %w", s.url, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("can't send record to %q: %w", s.url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("collector %q responded with status %q: %s", s.url, resp.Status, bytes.TrimSpace(body))
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return nil
+}
+
+func (s *HTTPSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,32 @@
+package results
+
+// Integrity is the outcome of verifying data written before the cluster was paused.
+type Integrity struct {
+	Partitions        int64   `json:"partitions"`
+	CheckedPartitions int64   `json:"checked_partitions"`
+	MissingPartitions []int64 `json:"missing_partitions,omitempty"`
+	CorruptPartitions []int64 `json:"corrupt_partitions,omitempty"`
+	VerificationMs    int64   `json:"verification_ms"`
+}
+
+const (
+	IntegrityCheckedPartitionsMetric = "checked_partitions"
+	IntegrityMissingPartitionsMetric = "missing_partitions"
+	IntegrityCorruptPartitionsMetric = "corrupt_partitions"
+	IntegrityVerificationMetric      = "verification_ms"
+
+	integrityMetricPrefix = "integrity/"
+)
+
+func IntegrityMetric(metric string) string {
+	return integrityMetricPrefix + metric
+}
+
+func (i *Integrity) metrics() []Metric {
+	return []Metric{
+		{Name: IntegrityMetric(IntegrityCheckedPartitionsMetric), Value: float64(i.CheckedPartitions), Unit: UnitCount},
+		{Name: IntegrityMetric(IntegrityMissingPartitionsMetric), Value: float64(len(i.MissingPartitions)), Unit: UnitCount},
+		{Name: IntegrityMetric(IntegrityCorruptPartitionsMetric), Value: float64(len(i.CorruptPartitions)), Unit: UnitCount},
+		{Name: IntegrityMetric(IntegrityVerificationMetric), Value: float64(i.VerificationMs), Unit: UnitMilliseconds},
+	}
+}
@@ -0,0 +1,32 @@
+package results
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRecordIntegrityMetrics(t *testing.T) {
+	t.Parallel()
+
+	startTime := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	record := NewRecord(&Metadata{RunID: "run"}, "cold", startTime, startTime.Add(20*time.Second))
+	record.Integrity = &Integrity{
+		Partitions:        1000,
+		CheckedPartitions: 459,
+		MissingPartitions: []int64{3},
+		CorruptPartitions: []int64{17, 512},
+		VerificationMs:    1500,
+	}
+
+	expected := []Metric{
+		{Name: "elapsed_time_ms", Value: 20000, Unit: UnitMilliseconds},
+		{Name: "integrity/checked_partitions", Value: 459, Unit: UnitCount},
+		{Name: "integrity/missing_partitions", Value: 1, Unit: UnitCount},
+		{Name: "integrity/corrupt_partitions", Value: 2, Unit: UnitCount},
+		{Name: "integrity/verification_ms", Value: 1500, Unit: UnitMilliseconds},
+	}
+	if got := record.Metrics(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected metrics %#v, got %#v", expected, got)
+	}
+}
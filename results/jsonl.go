@@ -0,0 +1,44 @@
+package results
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// JSONLinesFileSink appends records to a file, one JSON object per line.
+type JSONLinesFileSink struct {
+	lock sync.Mutex
+	file *os.File
+}
+
+var _ ResultSink = &JSONLinesFileSink{}
+
+func NewJSONLinesFileSink(filePath string) (*JSONLinesFileSink, error) {
+	f, err := os.OpenFile(filePath, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("can't open results file %q: %w", filePath, err)
+	}
+
+	return &JSONLinesFileSink{
+		file: f,
+	}, nil
+}
+
+func (s *JSONLinesFileSink) Write(_ context.Context, record *Record) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	err := json.NewEncoder(s.file).Encode(record)
+	if err != nil {
+		return fmt.Errorf("can't write record to %q: %w", s.file.Name(), err)
+	}
+
+	return nil
+}
+
+func (s *JSONLinesFileSink) Close() error {
+	return s.file.Close()
+}
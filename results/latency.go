@@ -0,0 +1,41 @@
+package results
+
+import (
+	"maps"
+	"slices"
+)
+
+// LatencySummary is a summary of a latency histogram in milliseconds.
+type LatencySummary struct {
+	Count  int64   `json:"count"`
+	MinMs  float64 `json:"min_ms"`
+	MeanMs float64 `json:"mean_ms"`
+	P50Ms  float64 `json:"p50_ms"`
+	P90Ms  float64 `json:"p90_ms"`
+	P99Ms  float64 `json:"p99_ms"`
+	P999Ms float64 `json:"p999_ms"`
+	MaxMs  float64 `json:"max_ms"`
+}
+
+const latencyMetricPrefix = "latency/"
+
+// LatencyMetric returns the name of a metric of the latency of an operation, e.g. "latency/read/p99_ms".
+func LatencyMetric(operation string, metric string) string {
+	return latencyMetricPrefix + operation + "/" + metric
+}
+
+func latencyMetrics(latencies map[string]LatencySummary) []Metric {
+	var metrics []Metric
+	for _, operation := range slices.Sorted(maps.Keys(latencies)) {
+		ls := latencies[operation]
+		metrics = append(metrics,
+			Metric{Name: LatencyMetric(operation, "p50_ms"), Value: ls.P50Ms, Unit: UnitMilliseconds},
+			Metric{Name: LatencyMetric(operation, "p90_ms"), Value: ls.P90Ms, Unit: UnitMilliseconds},
+			Metric{Name: LatencyMetric(operation, "p99_ms"), Value: ls.P99Ms, Unit: UnitMilliseconds},
+			Metric{Name: LatencyMetric(operation, "p999_ms"), Value: ls.P999Ms, Unit: UnitMilliseconds},
+			Metric{Name: LatencyMetric(operation, "max_ms"), Value: ls.MaxMs, Unit: UnitMilliseconds},
+		)
+	}
+
+	return metrics
+}
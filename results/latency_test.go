@@ -0,0 +1,35 @@
+package results
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRecordLatencyMetrics(t *testing.T) {
+	t.Parallel()
+
+	startTime := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	record := NewRecord(&Metadata{RunID: "run"}, "cold", startTime, startTime.Add(20*time.Second))
+	record.Latencies = map[string]LatencySummary{
+		"write": {Count: 10, P50Ms: 2, P90Ms: 3, P99Ms: 4, P999Ms: 5, MaxMs: 6},
+		"read":  {Count: 10, P50Ms: 1, P90Ms: 1.5, P99Ms: 2, P999Ms: 2.5, MaxMs: 3},
+	}
+
+	expected := []Metric{
+		{Name: "elapsed_time_ms", Value: 20000, Unit: UnitMilliseconds},
+		{Name: "latency/read/p50_ms", Value: 1, Unit: UnitMilliseconds},
+		{Name: "latency/read/p90_ms", Value: 1.5, Unit: UnitMilliseconds},
+		{Name: "latency/read/p99_ms", Value: 2, Unit: UnitMilliseconds},
+		{Name: "latency/read/p999_ms", Value: 2.5, Unit: UnitMilliseconds},
+		{Name: "latency/read/max_ms", Value: 3, Unit: UnitMilliseconds},
+		{Name: "latency/write/p50_ms", Value: 2, Unit: UnitMilliseconds},
+		{Name: "latency/write/p90_ms", Value: 3, Unit: UnitMilliseconds},
+		{Name: "latency/write/p99_ms", Value: 4, Unit: UnitMilliseconds},
+		{Name: "latency/write/p999_ms", Value: 5, Unit: UnitMilliseconds},
+		{Name: "latency/write/max_ms", Value: 6, Unit: UnitMilliseconds},
+	}
+	if got := record.Metrics(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected metrics %#v, got %#v", expected, got)
+	}
+}
@@ -0,0 +1,66 @@
+package results
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var ignoredFlagPrefixes = []string{
+	"test.",
+	"ginkgo.",
+}
+
+// Metadata describes a single benchmark run and is shared by all records it produces.
+type Metadata struct {
+	RunID      string
+	RandomSeed int64
+	Flags      map[string]string
+	Images     map[string]string
+}
+
+func NewMetadata(fs *flag.FlagSet, randomSeed int64, images []string) (*Metadata, error) {
+	imageDigests := make(map[string]string, len(images))
+	for _, image := range images {
+		name, digest, err := SplitImageDigest(image)
+		if err != nil {
+			return nil, err
+		}
+		imageDigests[name] = digest
+	}
+
+	return &Metadata{
+		RunID:      uuid.NewString(),
+		RandomSeed: randomSeed,
+		Flags:      FlagValues(fs),
+		Images:     imageDigests,
+	}, nil
+}
+
+// FlagValues returns values of all flags defined in the flag set, excluding flags of the testing frameworks.
+func FlagValues(fs *flag.FlagSet) map[string]string {
+	values := map[string]string{}
+	fs.VisitAll(func(f *flag.Flag) {
+		for _, prefix := range ignoredFlagPrefixes {
+			if strings.HasPrefix(f.Name, prefix) {
+				return
+			}
+		}
+
+		values[f.Name] = f.Value.String()
+	})
+
+	return values
+}
+
+// SplitImageDigest splits a digest-pinned image reference into its name and digest.
+func SplitImageDigest(image string) (string, string, error) {
+	name, digest, found := strings.Cut(image, "@")
+	if !found || len(name) == 0 || len(digest) == 0 {
+		return "", "", fmt.Errorf("image %q is not pinned by digest", image)
+	}
+
+	return name, digest, nil
+}
@@ -0,0 +1,98 @@
+package results
+
+import (
+	"errors"
+	"time"
+)
+
+// NodeTiming is the timeline of the application process of a single ScyllaDB node.
+type NodeTiming struct {
+	Pod              string    `json:"pod"`
+	Rack             string    `json:"rack,omitempty"`
+	ProcessStartTime time.Time `json:"process_start_time"`
+	ServingTime      time.Time `json:"serving_time"`
+	TimeToServeMs    int64     `json:"time_to_serve_ms"`
+	// CriticalPath is set on the node that started serving last, which the datacenter's readiness waits for.
+	CriticalPath bool `json:"critical_path,omitempty"`
+	// BootPhases are the phases of the process boot, relative to the process start.
+	BootPhases []Phase `json:"boot_phases,omitempty"`
+}
+
+func NewNodeTiming(pod, rack string, processStartTime, servingTime time.Time) NodeTiming {
+	return NodeTiming{
+		Pod:              pod,
+		Rack:             rack,
+		ProcessStartTime: processStartTime.UTC(),
+		ServingTime:      servingTime.UTC(),
+		TimeToServeMs:    servingTime.Sub(processStartTime).Milliseconds(),
+	}
+}
+
+// SetApplicationTimeline stores the node timings and splits the elapsed time into the application time
+// of the critical path node and the platform overhead before its process started and after it began serving.
+func (r *Record) SetApplicationTimeline(nodes []NodeTiming) error {
+	if len(nodes) == 0 {
+		return errors.New("at least one node timing is required")
+	}
+
+	criticalIdx := 0
+	for i := range nodes {
+		nodes[i].CriticalPath = false
+		if nodes[i].ServingTime.After(nodes[criticalIdx].ServingTime) {
+			criticalIdx = i
+		}
+	}
+	nodes[criticalIdx].CriticalPath = true
+	critical := nodes[criticalIdx]
+
+	r.Nodes = nodes
+	r.ApplicationTimeMs = critical.TimeToServeMs
+	r.OverheadBeforeApplicationMs = critical.ProcessStartTime.Sub(r.StartTime).Milliseconds()
+	r.OverheadAfterApplicationMs = r.EndTime.Sub(critical.ServingTime).Milliseconds()
+	r.OverheadTimeMs = r.OverheadBeforeApplicationMs + r.OverheadAfterApplicationMs
+
+	return nil
+}
+
+func (r *Record) CriticalPathNode() *NodeTiming {
+	for i := range r.Nodes {
+		if r.Nodes[i].CriticalPath {
+			return &r.Nodes[i]
+		}
+	}
+
+	return nil
+}
+
+// RackTiming summarizes the node timings of a single rack.
+type RackTiming struct {
+	Rack string
+	// ApplicationTimeMs is the time to serve of the slowest node in the rack.
+	ApplicationTimeMs int64
+	// ServingOffsetMs is the time from the start of the measurement until all nodes in the rack were serving.
+	ServingOffsetMs int64
+	Nodes           int
+	CriticalPath    bool
+}
+
+// RackTimings returns the node timings aggregated by rack, in the order the racks first appear in the nodes.
+func (r *Record) RackTimings() []RackTiming {
+	var rackTimings []RackTiming
+	rackIdx := map[string]int{}
+	for _, node := range r.Nodes {
+		idx, ok := rackIdx[node.Rack]
+		if !ok {
+			idx = len(rackTimings)
+			rackIdx[node.Rack] = idx
+			rackTimings = append(rackTimings, RackTiming{Rack: node.Rack})
+		}
+
+		rt := &rackTimings[idx]
+		rt.ApplicationTimeMs = max(rt.ApplicationTimeMs, node.TimeToServeMs)
+		rt.ServingOffsetMs = max(rt.ServingOffsetMs, node.ServingTime.Sub(r.StartTime).Milliseconds())
+		rt.Nodes++
+		rt.CriticalPath = rt.CriticalPath || node.CriticalPath
+	}
+
+	return rackTimings
+}
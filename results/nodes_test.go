@@ -0,0 +1,105 @@
+package results
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecordSetApplicationTimeline(t *testing.T) {
+	t.Parallel()
+
+	startTime := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	at := func(ms int64) time.Time {
+		return startTime.Add(time.Duration(ms) * time.Millisecond)
+	}
+
+	record := NewRecord(&Metadata{RunID: "run"}, "cold", startTime, at(10000))
+	err := record.SetApplicationTimeline([]NodeTiming{
+		NewNodeTiming("sdc-rack-0", "rack", at(2000), at(5000)),
+		NewNodeTiming("sdc-rack-1", "rack", at(1000), at(8000)),
+		NewNodeTiming("sdc-rack-2", "rack", at(3000), at(7000)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	criticalPathNode := record.CriticalPathNode()
+	if criticalPathNode == nil || criticalPathNode.Pod != "sdc-rack-1" {
+		t.Fatalf("expected the node serving last to be on the critical path, got %#v", criticalPathNode)
+	}
+
+	for _, node := range record.Nodes {
+		if node.Pod != criticalPathNode.Pod && node.CriticalPath {
+			t.Errorf("expected only a single node on the critical path, got %q", node.Pod)
+		}
+	}
+
+	if record.ApplicationTimeMs != 7000 {
+		t.Errorf("expected application time 7000ms, got %dms", record.ApplicationTimeMs)
+	}
+	if record.OverheadBeforeApplicationMs != 1000 || record.OverheadAfterApplicationMs != 2000 || record.OverheadTimeMs != 3000 {
+		t.Errorf("expected overhead 1000ms + 2000ms = 3000ms, got %dms + %dms = %dms", record.OverheadBeforeApplicationMs, record.OverheadAfterApplicationMs, record.OverheadTimeMs)
+	}
+
+	expectedMetrics := []Metric{
+		{Name: ElapsedTimeMetric, Value: 10000, Unit: UnitMilliseconds},
+		{Name: ApplicationTimeMetric, Value: 7000, Unit: UnitMilliseconds},
+		{Name: OverheadTimeMetric, Value: 3000, Unit: UnitMilliseconds},
+		{Name: OverheadBeforeApplicationMetric, Value: 1000, Unit: UnitMilliseconds},
+		{Name: OverheadAfterApplicationMetric, Value: 2000, Unit: UnitMilliseconds},
+		{Name: NodeTimeToServeSpreadMetric, Value: 4000, Unit: UnitMilliseconds},
+	}
+	if metrics := record.Metrics(); !reflect.DeepEqual(metrics, expectedMetrics) {
+		t.Errorf("expected metrics %#v, got %#v", expectedMetrics, metrics)
+	}
+
+	err = (&Record{}).SetApplicationTimeline(nil)
+	if err == nil {
+		t.Errorf("expected an error for no node timings")
+	}
+}
+
+func TestRecordRackTimings(t *testing.T) {
+	t.Parallel()
+
+	startTime := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	at := func(ms int64) time.Time {
+		return startTime.Add(time.Duration(ms) * time.Millisecond)
+	}
+
+	record := NewRecord(&Metadata{RunID: "run"}, "cold", startTime, at(10000))
+	err := record.SetApplicationTimeline([]NodeTiming{
+		NewNodeTiming("sdc-a-0", "a", at(2000), at(5000)),
+		NewNodeTiming("sdc-b-0", "b", at(1000), at(8000)),
+		NewNodeTiming("sdc-a-1", "a", at(1000), at(6000)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []RackTiming{
+		{Rack: "a", ApplicationTimeMs: 5000, ServingOffsetMs: 6000, Nodes: 2},
+		{Rack: "b", ApplicationTimeMs: 7000, ServingOffsetMs: 8000, Nodes: 1, CriticalPath: true},
+	}
+	if rackTimings := record.RackTimings(); !reflect.DeepEqual(rackTimings, expected) {
+		t.Errorf("expected rack timings %#v, got %#v", expected, rackTimings)
+	}
+
+	expectedRackMetrics := []Metric{
+		{Name: "rack/a/application_time_ms", Value: 5000, Unit: UnitMilliseconds},
+		{Name: "rack/a/serving_offset_ms", Value: 6000, Unit: UnitMilliseconds},
+		{Name: "rack/b/application_time_ms", Value: 7000, Unit: UnitMilliseconds},
+		{Name: "rack/b/serving_offset_ms", Value: 8000, Unit: UnitMilliseconds},
+	}
+	var rackMetrics []Metric
+	for _, m := range record.Metrics() {
+		if strings.HasPrefix(m.Name, rackMetricPrefix) {
+			rackMetrics = append(rackMetrics, m)
+		}
+	}
+	if !reflect.DeepEqual(rackMetrics, expectedRackMetrics) {
+		t.Errorf("expected rack metrics %#v, got %#v", expectedRackMetrics, rackMetrics)
+	}
+}
@@ -0,0 +1,228 @@
+package results
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	resultMetricNamePrefix = "benchmark_result_"
+	endTimestampMetricName = "benchmark_result_end_timestamp_seconds"
+	recordsMetricName      = "benchmark_result_records"
+)
+
+// resultMetricNameSuffixes are the suffixes of the names of result metric families, by the unit of their metrics,
+// in the order the families are written in.
+var resultMetricNameSuffixes = []struct {
+	unit   Unit
+	suffix string
+	help   string
+}{
+	{unit: UnitMilliseconds, suffix: "milliseconds", help: "Durations in the latest benchmark record of a scenario."},
+	{unit: UnitCount, suffix: "count", help: "Counts in the latest benchmark record of a scenario."},
+	{unit: UnitBytes, suffix: "bytes", help: "Sizes in the latest benchmark record of a scenario."},
+	{unit: UnitMillicores, suffix: "millicores", help: "CPU usage in the latest benchmark record of a scenario."},
+	{unit: UnitOpsPerSecond, suffix: "ops_per_second", help: "Throughputs in the latest benchmark record of a scenario."},
+}
+
+// reservedLabelNames are the names of labels set by the sink, which record labels can't use.
+var reservedLabelNames = []string{
+	"scenario",
+	"run_id",
+	"metric",
+}
+
+// OpenMetricsFileSink keeps a file in the OpenMetrics text format with the latest record of every scenario
+// and set of record labels, which can be picked up by the node exporter's textfile collector.
+// The file is rewritten atomically on every write.
+type OpenMetricsFileSink struct {
+	filePath string
+
+	lock sync.Mutex
+	// latestRecords are the latest records by their series key.
+	latestRecords map[string]*Record
+	recordCounts  map[string]int
+}
+
+var _ ResultSink = &OpenMetricsFileSink{}
+
+func NewOpenMetricsFileSink(filePath string) *OpenMetricsFileSink {
+	return &OpenMetricsFileSink{
+		filePath:      filePath,
+		latestRecords: map[string]*Record{},
+		recordCounts:  map[string]int{},
+	}
+}
+
+func (s *OpenMetricsFileSink) Write(_ context.Context, record *Record) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for name := range record.Labels {
+		if slices.Contains(reservedLabelNames, name) {
+			return fmt.Errorf("label %q of a record of scenario %q clashes with a label set by the sink", name, record.Scenario)
+		}
+	}
+
+	s.latestRecords[seriesKey(record)] = record
+	s.recordCounts[record.Scenario]++
+
+	buf := &bytes.Buffer{}
+	for _, mf := range s.metricFamilies() {
+		_, err := expfmt.MetricFamilyToOpenMetrics(buf, mf)
+		if err != nil {
+			return fmt.Errorf("can't encode metric family %q: %w", mf.GetName(), err)
+		}
+	}
+
+	_, err := expfmt.FinalizeOpenMetrics(buf)
+	if err != nil {
+		return fmt.Errorf("can't finalize OpenMetrics output: %w", err)
+	}
+
+	return writeFileAtomically(s.filePath, buf.Bytes())
+}
+
+func (s *OpenMetricsFileSink) Close() error {
+	return nil
+}
+
+func (s *OpenMetricsFileSink) metricFamilies() []*dto.MetricFamily {
+	// Measurements are split into a family per unit, so that values in different units don't share a name.
+	resultFamilies := map[Unit]*dto.MetricFamily{}
+	for _, ns := range resultMetricNameSuffixes {
+		resultFamilies[ns.unit] = &dto.MetricFamily{
+			Name: ptr.To(resultMetricNamePrefix + ns.suffix),
+			Help: ptr.To(ns.help),
+			Type: ptr.To(dto.MetricType_GAUGE),
+		}
+	}
+	endTimestampFamily := &dto.MetricFamily{
+		Name: ptr.To(endTimestampMetricName),
+		Help: ptr.To("End time of the latest benchmark record of a scenario."),
+		Type: ptr.To(dto.MetricType_GAUGE),
+	}
+	recordsFamily := &dto.MetricFamily{
+		Name: ptr.To(recordsMetricName),
+		Help: ptr.To("Number of benchmark records of a scenario written since the sink was created."),
+		Type: ptr.To(dto.MetricType_GAUGE),
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(s.latestRecords)) {
+		record := s.latestRecords[key]
+
+		for _, m := range record.Metrics() {
+			resultFamily := resultFamilies[m.Unit]
+			resultFamily.Metric = append(resultFamily.Metric, &dto.Metric{
+				Label: recordLabelPairs(record, "scenario", record.Scenario, "run_id", record.RunID, "metric", m.Name),
+				Gauge: &dto.Gauge{Value: ptr.To(m.Value)},
+			})
+		}
+
+		if !record.EndTime.IsZero() {
+			endTimestampFamily.Metric = append(endTimestampFamily.Metric, &dto.Metric{
+				Label: recordLabelPairs(record, "scenario", record.Scenario, "run_id", record.RunID),
+				Gauge: &dto.Gauge{Value: ptr.To(float64(record.EndTime.UnixMilli()) / 1000)},
+			})
+		}
+	}
+
+	for _, scenario := range slices.Sorted(maps.Keys(s.recordCounts)) {
+		recordsFamily.Metric = append(recordsFamily.Metric, &dto.Metric{
+			Label: labelPairs("scenario", scenario),
+			Gauge: &dto.Gauge{Value: ptr.To(float64(s.recordCounts[scenario]))},
+		})
+	}
+
+	var families []*dto.MetricFamily
+	for _, ns := range resultMetricNameSuffixes {
+		if mf := resultFamilies[ns.unit]; len(mf.Metric) != 0 {
+			families = append(families, mf)
+		}
+	}
+
+	for _, mf := range []*dto.MetricFamily{endTimestampFamily, recordsFamily} {
+		if len(mf.Metric) != 0 {
+			families = append(families, mf)
+		}
+	}
+
+	return families
+}
+
+// seriesKey identifies the records of a scenario that are kept side by side, i.e. the ones with different labels.
+// Keys sort by the scenario first.
+func seriesKey(record *Record) string {
+	var sb strings.Builder
+	sb.WriteString(record.Scenario)
+	for _, name := range slices.Sorted(maps.Keys(record.Labels)) {
+		fmt.Fprintf(&sb, "\x00%s=%s", name, record.Labels[name])
+	}
+
+	return sb.String()
+}
+
+// recordLabelPairs returns the label pairs followed by the labels of the record, sorted by name.
+func recordLabelPairs(record *Record, nameValues ...string) []*dto.LabelPair {
+	for _, name := range slices.Sorted(maps.Keys(record.Labels)) {
+		nameValues = append(nameValues, name, record.Labels[name])
+	}
+
+	return labelPairs(nameValues...)
+}
+
+func labelPairs(nameValues ...string) []*dto.LabelPair {
+	var pairs []*dto.LabelPair
+	for i := 0; i+1 < len(nameValues); i += 2 {
+		pairs = append(pairs, &dto.LabelPair{
+			Name:  ptr.To(nameValues[i]),
+			Value: ptr.To(nameValues[i+1]),
+		})
+	}
+
+	return pairs
+}
+
+func writeFileAtomically(filePath string, data []byte) error {
+	dir, base := filepath.Split(filePath)
+	f, err := os.CreateTemp(dir, "."+strings.TrimPrefix(base, ".")+".tmp-")
+	if err != nil {
+		return fmt.Errorf("can't create temporary file for %q: %w", filePath, err)
+	}
+	defer os.Remove(f.Name())
+
+	_, err = f.Write(data)
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("can't write temporary file for %q: %w", filePath, err)
+	}
+
+	err = f.Chmod(0644)
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("can't change mode of temporary file for %q: %w", filePath, err)
+	}
+
+	err = f.Close()
+	if err != nil {
+		return fmt.Errorf("can't close temporary file for %q: %w", filePath, err)
+	}
+
+	err = os.Rename(f.Name(), filePath)
+	if err != nil {
+		return fmt.Errorf("can't rename temporary file to %q: %w", filePath, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package results
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// ReadRecords reads newline-delimited JSON records.
+// Legacy records, written before the schema was versioned, are accepted and have SchemaVersion set to zero.
+func ReadRecords(r io.Reader) ([]*Record, error) {
+	var records []*Record
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		record := &Record{}
+		err := json.Unmarshal(line, record)
+		if err != nil {
+			return nil, fmt.Errorf("can't decode record on line %d: %w", lineNumber, err)
+		}
+
+		if record.SchemaVersion > SchemaVersion {
+			return nil, fmt.Errorf("record on line %d has unsupported schema version %d, the newest supported version is %d", lineNumber, record.SchemaVersion, SchemaVersion)
+		}
+
+		records = append(records, record)
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("can't read records: %w", err)
+	}
+
+	return records, nil
+}
+
+// ReadRecordsFile reads records from a results file.
+// Records without a scenario, such as legacy records, get the scenario from the file name.
+func ReadRecordsFile(filePath string) ([]*Record, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("can't open results file %q: %w", filePath, err)
+	}
+	defer f.Close()
+
+	records, err := ReadRecords(f)
+	if err != nil {
+		return nil, fmt.Errorf("can't read results file %q: %w", filePath, err)
+	}
+
+	scenario := filepath.Base(filePath)
+	for _, record := range records {
+		if len(record.Scenario) == 0 {
+			record.Scenario = scenario
+		}
+	}
+
+	return records, nil
+}
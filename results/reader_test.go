@@ -0,0 +1,110 @@
+package results
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadRecords(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name            string
+		input           string
+		expectedRecords []*Record
+		expectedErr     bool
+	}{
+		{
+			name:  "legacy records",
+			input: `{"elapsed_time_ms":40515,"application_time_ms":21632,"overhead_time_ms":18883}` + "\n" + `{"elapsed_time_ms":661}` + "\n",
+			expectedRecords: []*Record{
+				{
+					ElapsedTimeMs:     40515,
+					ApplicationTimeMs: 21632,
+					OverheadTimeMs:    18883,
+				},
+				{
+					ElapsedTimeMs: 661,
+				},
+			},
+		},
+		{
+			name:  "versioned record",
+			input: `{"schema_version":1,"run_id":"run","scenario":"cold","start_time":"2025-03-01T10:00:00Z","end_time":"2025-03-01T10:00:40Z","random_seed":42,"flags":{"nodes":"3"},"images":{"docker.io/scylladb/scylla:6.2.3":"sha256:abc"},"elapsed_time_ms":40000,"application_time_ms":20000,"overhead_time_ms":20000}`,
+			expectedRecords: []*Record{
+				{
+					SchemaVersion:     1,
+					RunID:             "run",
+					Scenario:          "cold",
+					StartTime:         time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC),
+					EndTime:           time.Date(2025, 3, 1, 10, 0, 40, 0, time.UTC),
+					RandomSeed:        42,
+					Flags:             map[string]string{"nodes": "3"},
+					Images:            map[string]string{"docker.io/scylladb/scylla:6.2.3": "sha256:abc"},
+					ElapsedTimeMs:     40000,
+					ApplicationTimeMs: 20000,
+					OverheadTimeMs:    20000,
+				},
+			},
+		},
+		{
+			name:            "blank lines are skipped",
+			input:           "\n" + `{"elapsed_time_ms":1}` + "\n\n",
+			expectedRecords: []*Record{{ElapsedTimeMs: 1}},
+		},
+		{
+			name:        "unsupported schema version",
+			input:       `{"schema_version":1000,"elapsed_time_ms":1}`,
+			expectedErr: true,
+		},
+		{
+			name:        "malformed line",
+			input:       `{"elapsed_time_ms":`,
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			records, err := ReadRecords(strings.NewReader(tc.input))
+			if tc.expectedErr != (err != nil) {
+				t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+			}
+
+			if !reflect.DeepEqual(records, tc.expectedRecords) {
+				t.Errorf("expected records %#v, got %#v", tc.expectedRecords, records)
+			}
+		})
+	}
+}
+
+func TestReadRecordsFile(t *testing.T) {
+	t.Parallel()
+
+	filePath := filepath.Join(t.TempDir(), "prewarmed")
+	err := os.WriteFile(filePath, []byte(`{"elapsed_time_ms":1}`+"\n"+`{"schema_version":1,"scenario":"other","elapsed_time_ms":2}`+"\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := ReadRecordsFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var scenarios []string
+	for _, r := range records {
+		scenarios = append(scenarios, r.Scenario)
+	}
+
+	expectedScenarios := []string{"prewarmed", "other"}
+	if !reflect.DeepEqual(scenarios, expectedScenarios) {
+		t.Errorf("expected scenarios %v, got %v", expectedScenarios, scenarios)
+	}
+}
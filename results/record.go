@@ -0,0 +1,195 @@
+package results
+
+import (
+	"time"
+)
+
+// SchemaVersion is the version of the Record schema written by this package.
+// Records with no schema version are legacy records that only carry timing fields.
+const SchemaVersion = 1
+
+type Record struct {
+	SchemaVersion int `json:"schema_version"`
+
+	RunID      string            `json:"run_id,omitempty"`
+	Scenario   string            `json:"scenario,omitempty"`
+	StartTime  time.Time         `json:"start_time,omitzero"`
+	EndTime    time.Time         `json:"end_time,omitzero"`
+	RandomSeed int64             `json:"random_seed,omitempty"`
+	Flags      map[string]string `json:"flags,omitempty"`
+	Images     map[string]string `json:"images,omitempty"`
+	// Labels distinguish records of the same scenario, e.g. by the measured object.
+	Labels map[string]string `json:"labels,omitempty"`
+	// DataSizeBytes is the amount of data written to the cluster before the measurement.
+	DataSizeBytes int64 `json:"data_size_bytes,omitempty"`
+	// ReplicationFactor and Consistency are the replication factor of the keyspaces and the consistency level
+	// of the traffic used in the measurement.
+	ReplicationFactor int    `json:"replication_factor,omitempty"`
+	Consistency       string `json:"consistency,omitempty"`
+	// VolumeSizeBytes, FSType, VolumeAccessMode and VolumeMode describe the backend volumes used in the measurement,
+	// of which there are VolumeCount per pod.
+	VolumeSizeBytes  int64  `json:"volume_size_bytes,omitempty"`
+	FSType           string `json:"fs_type,omitempty"`
+	VolumeAccessMode string `json:"volume_access_mode,omitempty"`
+	VolumeMode       string `json:"volume_mode,omitempty"`
+	VolumeCount      int    `json:"volume_count,omitempty"`
+
+	ElapsedTimeMs     int64 `json:"elapsed_time_ms"`
+	ApplicationTimeMs int64 `json:"application_time_ms,omitempty"`
+	OverheadTimeMs    int64 `json:"overhead_time_ms,omitempty"`
+
+	// TimeToSteadyStateMs is the time from the start of the measurement until both latency and throughput
+	// under load got back to their baseline.
+	TimeToSteadyStateMs int64 `json:"time_to_steady_state_ms,omitempty"`
+
+	OverheadBeforeApplicationMs int64 `json:"overhead_before_application_ms,omitempty"`
+	OverheadAfterApplicationMs  int64 `json:"overhead_after_application_ms,omitempty"`
+
+	Phases       []Phase       `json:"phases,omitempty"`
+	Nodes        []NodeTiming  `json:"nodes,omitempty"`
+	Availability *Availability `json:"availability,omitempty"`
+	SteadyState  *SteadyState  `json:"steady_state,omitempty"`
+	// Latencies summarize latencies of operations of a workload run during the measurement, by operation.
+	Latencies map[string]LatencySummary `json:"latencies,omitempty"`
+	Integrity *Integrity                `json:"integrity,omitempty"`
+	// Connections are timings of the first connections to every host, in the order they were established.
+	Connections []HostConnection `json:"connections,omitempty"`
+	// Resources is the resource usage of the measured pod while it was idle before the measurement.
+	Resources *ResourceUsage `json:"resources,omitempty"`
+}
+
+// Phase is a part of the measured interval that ends when a milestone is reached.
+type Phase struct {
+	Name string `json:"name"`
+	// OffsetMs is the time from the start of the measurement to the end of the phase.
+	OffsetMs   int64 `json:"offset_ms"`
+	DurationMs int64 `json:"duration_ms"`
+}
+
+func (r *Record) IsLegacy() bool {
+	return r.SchemaVersion == 0
+}
+
+// NewRecord creates a Record for the scenario, populated with the run metadata.
+func NewRecord(metadata *Metadata, scenario string, startTime, endTime time.Time) *Record {
+	return &Record{
+		SchemaVersion: SchemaVersion,
+		RunID:         metadata.RunID,
+		Scenario:      scenario,
+		StartTime:     startTime.UTC(),
+		EndTime:       endTime.UTC(),
+		RandomSeed:    metadata.RandomSeed,
+		Flags:         metadata.Flags,
+		Images:        metadata.Images,
+		ElapsedTimeMs: endTime.Sub(startTime).Milliseconds(),
+	}
+}
+
+const (
+	ElapsedTimeMetric     = "elapsed_time_ms"
+	ApplicationTimeMetric = "application_time_ms"
+	OverheadTimeMetric    = "overhead_time_ms"
+
+	OverheadBeforeApplicationMetric = "overhead_before_application_ms"
+	OverheadAfterApplicationMetric  = "overhead_after_application_ms"
+	NodeTimeToServeSpreadMetric     = "node_time_to_serve_spread_ms"
+	RackServingOffsetMetric         = "serving_offset_ms"
+
+	phaseMetricPrefix = "phase/"
+	rackMetricPrefix  = "rack/"
+)
+
+func PhaseMetric(phaseName string) string {
+	return phaseMetricPrefix + phaseName + "_ms"
+}
+
+func RackMetric(rackName string, metric string) string {
+	return rackMetricPrefix + rackName + "/" + metric
+}
+
+// Unit is the unit of the value of a metric.
+type Unit string
+
+const (
+	UnitMilliseconds Unit = "milliseconds"
+	UnitCount        Unit = "count"
+	UnitBytes        Unit = "bytes"
+	UnitMillicores   Unit = "millicores"
+	UnitOpsPerSecond Unit = "ops_per_second"
+)
+
+type Metric struct {
+	Name  string
+	Value float64
+	Unit  Unit
+	// HigherIsBetter is set for metrics that improve when they grow, e.g. throughput.
+	HigherIsBetter bool
+}
+
+// Metrics returns all measurements of the record, ordered from the most general to the most specific.
+func (r *Record) Metrics() []Metric {
+	metrics := []Metric{
+		{Name: ElapsedTimeMetric, Value: float64(r.ElapsedTimeMs), Unit: UnitMilliseconds},
+	}
+
+	if r.TimeToSteadyStateMs != 0 {
+		metrics = append(metrics, Metric{Name: TimeToSteadyStateMetric, Value: float64(r.TimeToSteadyStateMs), Unit: UnitMilliseconds})
+	}
+
+	if r.ApplicationTimeMs != 0 || r.OverheadTimeMs != 0 {
+		metrics = append(metrics,
+			Metric{Name: ApplicationTimeMetric, Value: float64(r.ApplicationTimeMs), Unit: UnitMilliseconds},
+			Metric{Name: OverheadTimeMetric, Value: float64(r.OverheadTimeMs), Unit: UnitMilliseconds},
+		)
+	}
+
+	if len(r.Nodes) != 0 {
+		minTimeToServeMs, maxTimeToServeMs := r.Nodes[0].TimeToServeMs, r.Nodes[0].TimeToServeMs
+		for _, node := range r.Nodes[1:] {
+			minTimeToServeMs = min(minTimeToServeMs, node.TimeToServeMs)
+			maxTimeToServeMs = max(maxTimeToServeMs, node.TimeToServeMs)
+		}
+
+		metrics = append(metrics,
+			Metric{Name: OverheadBeforeApplicationMetric, Value: float64(r.OverheadBeforeApplicationMs), Unit: UnitMilliseconds},
+			Metric{Name: OverheadAfterApplicationMetric, Value: float64(r.OverheadAfterApplicationMs), Unit: UnitMilliseconds},
+			Metric{Name: NodeTimeToServeSpreadMetric, Value: float64(maxTimeToServeMs - minTimeToServeMs), Unit: UnitMilliseconds},
+		)
+	}
+
+	// Racks are only broken down when there is more than one, as a single rack would repeat the totals.
+	if rackTimings := r.RackTimings(); len(rackTimings) > 1 {
+		for _, rt := range rackTimings {
+			metrics = append(metrics,
+				Metric{Name: RackMetric(rt.Rack, ApplicationTimeMetric), Value: float64(rt.ApplicationTimeMs), Unit: UnitMilliseconds},
+				Metric{Name: RackMetric(rt.Rack, RackServingOffsetMetric), Value: float64(rt.ServingOffsetMs), Unit: UnitMilliseconds},
+			)
+		}
+	}
+
+	for _, phase := range r.Phases {
+		metrics = append(metrics, Metric{Name: PhaseMetric(phase.Name), Value: float64(phase.DurationMs), Unit: UnitMilliseconds})
+	}
+
+	if r.Availability != nil {
+		metrics = append(metrics, r.Availability.metrics()...)
+	}
+
+	if r.SteadyState != nil {
+		metrics = append(metrics, r.SteadyState.metrics()...)
+	}
+
+	metrics = append(metrics, latencyMetrics(r.Latencies)...)
+
+	if r.Integrity != nil {
+		metrics = append(metrics, r.Integrity.metrics()...)
+	}
+
+	metrics = append(metrics, connectionsMetrics(r.Connections)...)
+
+	if r.Resources != nil {
+		metrics = append(metrics, r.Resources.metrics()...)
+	}
+
+	return metrics
+}
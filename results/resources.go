@@ -0,0 +1,32 @@
+package results
+
+// ResourceUsage is the resource usage of a pod sampled over a window.
+type ResourceUsage struct {
+	Samples  int   `json:"samples"`
+	WindowMs int64 `json:"window_ms"`
+	// CPUMillicores is the average CPU usage over the window.
+	CPUMillicores float64 `json:"cpu_millicores"`
+	// MemoryWorkingSetBytes is the average memory working set over the samples.
+	MemoryWorkingSetBytes    int64 `json:"memory_working_set_bytes"`
+	MaxMemoryWorkingSetBytes int64 `json:"max_memory_working_set_bytes"`
+}
+
+const (
+	ResourcesCPUMetric       = "cpu_millicores"
+	ResourcesMemoryMetric    = "memory_working_set_bytes"
+	ResourcesMaxMemoryMetric = "max_memory_working_set_bytes"
+
+	resourcesMetricPrefix = "resources/"
+)
+
+func ResourcesMetric(metric string) string {
+	return resourcesMetricPrefix + metric
+}
+
+func (ru *ResourceUsage) metrics() []Metric {
+	return []Metric{
+		{Name: ResourcesMetric(ResourcesCPUMetric), Value: ru.CPUMillicores, Unit: UnitMillicores},
+		{Name: ResourcesMetric(ResourcesMemoryMetric), Value: float64(ru.MemoryWorkingSetBytes), Unit: UnitBytes},
+		{Name: ResourcesMetric(ResourcesMaxMemoryMetric), Value: float64(ru.MaxMemoryWorkingSetBytes), Unit: UnitBytes},
+	}
+}
@@ -0,0 +1,31 @@
+package results
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRecordResourcesMetrics(t *testing.T) {
+	t.Parallel()
+
+	startTime := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	record := NewRecord(&Metadata{RunID: "run"}, "busywait", startTime, startTime.Add(3*time.Second))
+	record.Resources = &ResourceUsage{
+		Samples:                  5,
+		WindowMs:                 40000,
+		CPUMillicores:            998.5,
+		MemoryWorkingSetBytes:    1 << 20,
+		MaxMemoryWorkingSetBytes: 2 << 20,
+	}
+
+	expected := []Metric{
+		{Name: "elapsed_time_ms", Value: 3000, Unit: UnitMilliseconds},
+		{Name: "resources/cpu_millicores", Value: 998.5, Unit: UnitMillicores},
+		{Name: "resources/memory_working_set_bytes", Value: 1 << 20, Unit: UnitBytes},
+		{Name: "resources/max_memory_working_set_bytes", Value: 2 << 20, Unit: UnitBytes},
+	}
+	if got := record.Metrics(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected metrics %#v, got %#v", expected, got)
+	}
+}
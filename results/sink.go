@@ -0,0 +1,154 @@
+package results
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// ResultSink persists records produced by benchmark scenarios.
+type ResultSink interface {
+	Write(ctx context.Context, record *Record) error
+	Close() error
+}
+
+type MultiSink struct {
+	sinks []ResultSink
+}
+
+var _ ResultSink = &MultiSink{}
+
+// NewMultiSink creates a sink that writes every record to all the given sinks.
+func NewMultiSink(sinks ...ResultSink) *MultiSink {
+	return &MultiSink{
+		sinks: sinks,
+	}
+}
+
+func (s *MultiSink) Write(ctx context.Context, record *Record) error {
+	var errs []error
+	for _, sink := range s.sinks {
+		errs = append(errs, sink.Write(ctx, record))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *MultiSink) Close() error {
+	var errs []error
+	for _, sink := range s.sinks {
+		errs = append(errs, sink.Close())
+	}
+
+	return errors.Join(errs...)
+}
+
+const (
+	FormatJSONLines   = "jsonl"
+	FormatCSV         = "csv"
+	FormatOpenMetrics = "openmetrics"
+)
+
+var SupportedFormats = []string{
+	FormatJSONLines,
+	FormatCSV,
+	FormatOpenMetrics,
+}
+
+type SinkOptions struct {
+	// Formats are the formats of the files written to DestDir, one file per format.
+	Formats []string
+	// DestDir is the directory the files are written to. The caller is responsible for validating that it exists.
+	DestDir string
+	// CollectorURL, if set, is the URL records are additionally sent to.
+	CollectorURL string
+}
+
+// ParseFormats parses a comma-separated list of formats.
+func ParseFormats(s string) ([]string, error) {
+	var formats []string
+	for _, format := range strings.Split(s, ",") {
+		format = strings.TrimSpace(format)
+		if len(format) == 0 {
+			continue
+		}
+
+		if !slices.Contains(SupportedFormats, format) {
+			return nil, fmt.Errorf("unsupported result format %q, supported formats are: %v", format, SupportedFormats)
+		}
+
+		formats = append(formats, format)
+	}
+
+	return formats, nil
+}
+
+func (so *SinkOptions) Validate() error {
+	var errs []error
+
+	for _, format := range so.Formats {
+		if !slices.Contains(SupportedFormats, format) {
+			errs = append(errs, fmt.Errorf("unsupported result format %q, supported formats are: %v", format, SupportedFormats))
+		}
+	}
+
+	if len(so.CollectorURL) != 0 {
+		u, err := url.Parse(so.CollectorURL)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("can't parse collector URL %q: %w", so.CollectorURL, err))
+		} else if u.Scheme != "http" && u.Scheme != "https" {
+			errs = append(errs, fmt.Errorf("collector URL %q must use http or https scheme", so.CollectorURL))
+		}
+	}
+
+	if len(so.Formats) == 0 && len(so.CollectorURL) == 0 {
+		errs = append(errs, fmt.Errorf("at least one result format or a collector URL has to be set"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// NewSink creates a sink writing records to all configured destinations.
+// JSON lines are written to a file named after the results, other formats append their extension to it.
+func NewSink(options SinkOptions, resultsName string) (ResultSink, error) {
+	var sinks []ResultSink
+	closeSinks := func() {
+		_ = NewMultiSink(sinks...).Close()
+	}
+
+	for _, format := range options.Formats {
+		var sink ResultSink
+		var err error
+
+		switch format {
+		case FormatJSONLines:
+			sink, err = NewJSONLinesFileSink(filepath.Join(options.DestDir, resultsName))
+		case FormatCSV:
+			sink, err = NewCSVFileSink(filepath.Join(options.DestDir, resultsName+".csv"))
+		case FormatOpenMetrics:
+			sink = NewOpenMetricsFileSink(filepath.Join(options.DestDir, resultsName+".prom"))
+		default:
+			err = fmt.Errorf("unsupported result format %q", format)
+		}
+		if err != nil {
+			closeSinks()
+			return nil, err
+		}
+
+		sinks = append(sinks, sink)
+	}
+
+	if len(options.CollectorURL) != 0 {
+		sinks = append(sinks, NewHTTPSink(options.CollectorURL))
+	}
+
+	if len(sinks) == 1 {
+		return sinks[0], nil
+	}
+
+	return NewMultiSink(sinks...), nil
+}
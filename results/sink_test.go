@@ -0,0 +1,371 @@
+package results
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestRecord(scenario string, elapsedTimeMs int64) *Record {
+	startTime := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	r := NewRecord(&Metadata{RunID: "run"}, scenario, startTime, startTime.Add(time.Duration(elapsedTimeMs)*time.Millisecond))
+	r.ApplicationTimeMs = elapsedTimeMs / 2
+	r.OverheadTimeMs = elapsedTimeMs - r.ApplicationTimeMs
+	r.Phases = []Phase{
+		{Name: "pods-scheduled", OffsetMs: 100, DurationMs: 100},
+	}
+
+	return r
+}
+
+func TestJSONLinesFileSink(t *testing.T) {
+	t.Parallel()
+
+	filePath := filepath.Join(t.TempDir(), "cold")
+	err := os.WriteFile(filePath, []byte(`{"elapsed_time_ms":1}`+"\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sink, err := NewJSONLinesFileSink(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record := newTestRecord("cold", 2000)
+	err = sink.Write(context.Background(), record)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = sink.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := ReadRecordsFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedRecords := []*Record{{Scenario: "cold", ElapsedTimeMs: 1}, record}
+	if !reflect.DeepEqual(records, expectedRecords) {
+		t.Errorf("expected records %#v, got %#v", expectedRecords, records)
+	}
+}
+
+func TestCSVFileSink(t *testing.T) {
+	t.Parallel()
+
+	filePath := filepath.Join(t.TempDir(), "cold.csv")
+
+	// Reopen the sink to verify the header is only written once.
+	for _, elapsedTimeMs := range []int64{2000, 3000} {
+		sink, err := NewCSVFileSink(filePath)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = sink.Write(context.Background(), newTestRecord("cold", elapsedTimeMs))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = sink.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := strings.TrimPrefix(`
+schema_version,run_id,scenario,start_time,end_time,metric,value
+1,run,cold,2025-03-01T10:00:00Z,2025-03-01T10:00:02Z,elapsed_time_ms,2000
+1,run,cold,2025-03-01T10:00:00Z,2025-03-01T10:00:02Z,application_time_ms,1000
+1,run,cold,2025-03-01T10:00:00Z,2025-03-01T10:00:02Z,overhead_time_ms,1000
+1,run,cold,2025-03-01T10:00:00Z,2025-03-01T10:00:02Z,phase/pods-scheduled_ms,100
+1,run,cold,2025-03-01T10:00:00Z,2025-03-01T10:00:03Z,elapsed_time_ms,3000
+1,run,cold,2025-03-01T10:00:00Z,2025-03-01T10:00:03Z,application_time_ms,1500
+1,run,cold,2025-03-01T10:00:00Z,2025-03-01T10:00:03Z,overhead_time_ms,1500
+1,run,cold,2025-03-01T10:00:00Z,2025-03-01T10:00:03Z,phase/pods-scheduled_ms,100
+`, "\n")
+	if string(data) != expected {
+		t.Errorf("expected file content:\n%s\ngot:\n%s", expected, data)
+	}
+}
+
+func TestOpenMetricsFileSink(t *testing.T) {
+	t.Parallel()
+
+	filePath := filepath.Join(t.TempDir(), "results.prom")
+	sink := NewOpenMetricsFileSink(filePath)
+
+	prewarmedRecord := newTestRecord("prewarmed", 1000)
+	prewarmedRecord.Resources = &ResourceUsage{
+		Samples:                  2,
+		WindowMs:                 1000,
+		CPUMillicores:            12.5,
+		MemoryWorkingSetBytes:    1 << 20,
+		MaxMemoryWorkingSetBytes: 2 << 20,
+	}
+	prewarmedRecord.Integrity = &Integrity{
+		Partitions:        4,
+		CheckedPartitions: 4,
+		VerificationMs:    20,
+	}
+	for _, record := range []*Record{prewarmedRecord, newTestRecord("cold", 2000), newTestRecord("cold", 3000)} {
+		err := sink.Write(context.Background(), record)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := strings.TrimPrefix(`
+# HELP benchmark_result_milliseconds Durations in the latest benchmark record of a scenario.
+# TYPE benchmark_result_milliseconds gauge
+benchmark_result_milliseconds{scenario="cold",run_id="run",metric="elapsed_time_ms"} 3000.0
+benchmark_result_milliseconds{scenario="cold",run_id="run",metric="application_time_ms"} 1500.0
+benchmark_result_milliseconds{scenario="cold",run_id="run",metric="overhead_time_ms"} 1500.0
+benchmark_result_milliseconds{scenario="cold",run_id="run",metric="phase/pods-scheduled_ms"} 100.0
+benchmark_result_milliseconds{scenario="prewarmed",run_id="run",metric="elapsed_time_ms"} 1000.0
+benchmark_result_milliseconds{scenario="prewarmed",run_id="run",metric="application_time_ms"} 500.0
+benchmark_result_milliseconds{scenario="prewarmed",run_id="run",metric="overhead_time_ms"} 500.0
+benchmark_result_milliseconds{scenario="prewarmed",run_id="run",metric="phase/pods-scheduled_ms"} 100.0
+benchmark_result_milliseconds{scenario="prewarmed",run_id="run",metric="integrity/verification_ms"} 20.0
+# HELP benchmark_result_count Counts in the latest benchmark record of a scenario.
+# TYPE benchmark_result_count gauge
+benchmark_result_count{scenario="prewarmed",run_id="run",metric="integrity/checked_partitions"} 4.0
+benchmark_result_count{scenario="prewarmed",run_id="run",metric="integrity/missing_partitions"} 0.0
+benchmark_result_count{scenario="prewarmed",run_id="run",metric="integrity/corrupt_partitions"} 0.0
+# HELP benchmark_result_bytes Sizes in the latest benchmark record of a scenario.
+# TYPE benchmark_result_bytes gauge
+benchmark_result_bytes{scenario="prewarmed",run_id="run",metric="resources/memory_working_set_bytes"} 1.048576e+06
+benchmark_result_bytes{scenario="prewarmed",run_id="run",metric="resources/max_memory_working_set_bytes"} 2.097152e+06
+# HELP benchmark_result_millicores CPU usage in the latest benchmark record of a scenario.
+# TYPE benchmark_result_millicores gauge
+benchmark_result_millicores{scenario="prewarmed",run_id="run",metric="resources/cpu_millicores"} 12.5
+# HELP benchmark_result_end_timestamp_seconds End time of the latest benchmark record of a scenario.
+# TYPE benchmark_result_end_timestamp_seconds gauge
+benchmark_result_end_timestamp_seconds{scenario="cold",run_id="run"} 1.740823203e+09
+benchmark_result_end_timestamp_seconds{scenario="prewarmed",run_id="run"} 1.740823201e+09
+# HELP benchmark_result_records Number of benchmark records of a scenario written since the sink was created.
+# TYPE benchmark_result_records gauge
+benchmark_result_records{scenario="cold"} 2.0
+benchmark_result_records{scenario="prewarmed"} 1.0
+# EOF
+`, "\n")
+	if string(data) != expected {
+		t.Errorf("expected file content:\n%s\ngot:\n%s", expected, data)
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(filePath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("expected temporary files to be removed, got %d entries", len(entries))
+	}
+}
+
+func TestOpenMetricsFileSinkLabels(t *testing.T) {
+	t.Parallel()
+
+	filePath := filepath.Join(t.TempDir(), "results.prom")
+	sink := NewOpenMetricsFileSink(filePath)
+
+	for _, pod := range []string{"pod-b", "pod-a", "pod-b"} {
+		record := newTestRecord("density", 2000)
+		record.Phases = nil
+		record.Labels = map[string]string{"pod": pod}
+		err := sink.Write(context.Background(), record)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := strings.TrimPrefix(`
+# HELP benchmark_result_milliseconds Durations in the latest benchmark record of a scenario.
+# TYPE benchmark_result_milliseconds gauge
+benchmark_result_milliseconds{scenario="density",run_id="run",metric="elapsed_time_ms",pod="pod-a"} 2000.0
+benchmark_result_milliseconds{scenario="density",run_id="run",metric="application_time_ms",pod="pod-a"} 1000.0
+benchmark_result_milliseconds{scenario="density",run_id="run",metric="overhead_time_ms",pod="pod-a"} 1000.0
+benchmark_result_milliseconds{scenario="density",run_id="run",metric="elapsed_time_ms",pod="pod-b"} 2000.0
+benchmark_result_milliseconds{scenario="density",run_id="run",metric="application_time_ms",pod="pod-b"} 1000.0
+benchmark_result_milliseconds{scenario="density",run_id="run",metric="overhead_time_ms",pod="pod-b"} 1000.0
+# HELP benchmark_result_end_timestamp_seconds End time of the latest benchmark record of a scenario.
+# TYPE benchmark_result_end_timestamp_seconds gauge
+benchmark_result_end_timestamp_seconds{scenario="density",run_id="run",pod="pod-a"} 1.740823202e+09
+benchmark_result_end_timestamp_seconds{scenario="density",run_id="run",pod="pod-b"} 1.740823202e+09
+# HELP benchmark_result_records Number of benchmark records of a scenario written since the sink was created.
+# TYPE benchmark_result_records gauge
+benchmark_result_records{scenario="density"} 3.0
+# EOF
+`, "\n")
+	if string(data) != expected {
+		t.Errorf("expected file content:\n%s\ngot:\n%s", expected, data)
+	}
+
+	record := newTestRecord("density", 2000)
+	record.Labels = map[string]string{"scenario": "other"}
+	err = sink.Write(context.Background(), record)
+	if err == nil {
+		t.Errorf("expected an error for a label clashing with the labels set by the sink")
+	}
+}
+
+func TestHTTPSink(t *testing.T) {
+	t.Parallel()
+
+	var lock sync.Mutex
+	var received []*Record
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		record := &Record{}
+		err = json.Unmarshal(body, record)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if record.Scenario == "rejected" {
+			http.Error(w, "rejected by collector", http.StatusUnprocessableEntity)
+			return
+		}
+
+		lock.Lock()
+		defer lock.Unlock()
+		received = append(received, record)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	sink := NewHTTPSink(server.URL)
+	defer sink.Close()
+
+	record := newTestRecord("cold", 2000)
+	err := sink.Write(context.Background(), record)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lock.Lock()
+	if !reflect.DeepEqual(received, []*Record{record}) {
+		t.Errorf("expected collector to receive %#v, got %#v", []*Record{record}, received)
+	}
+	lock.Unlock()
+
+	err = sink.Write(context.Background(), newTestRecord("rejected", 1000))
+	if err == nil || !strings.Contains(err.Error(), "rejected by collector") {
+		t.Errorf("expected error containing collector's response, got %v", err)
+	}
+}
+
+type fakeSink struct {
+	records  []*Record
+	writeErr error
+	closed   bool
+}
+
+func (s *fakeSink) Write(_ context.Context, record *Record) error {
+	s.records = append(s.records, record)
+	return s.writeErr
+}
+
+func (s *fakeSink) Close() error {
+	s.closed = true
+	return nil
+}
+
+func TestMultiSink(t *testing.T) {
+	t.Parallel()
+
+	writeErr := errors.New("write error")
+	failing := &fakeSink{writeErr: writeErr}
+	succeeding := &fakeSink{}
+	sink := NewMultiSink(failing, succeeding)
+
+	record := newTestRecord("cold", 1000)
+	err := sink.Write(context.Background(), record)
+	if !errors.Is(err, writeErr) {
+		t.Errorf("expected error %v, got %v", writeErr, err)
+	}
+
+	err = sink.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, s := range []*fakeSink{failing, succeeding} {
+		if !reflect.DeepEqual(s.records, []*Record{record}) {
+			t.Errorf("expected every sink to receive the record, got %#v", s.records)
+		}
+
+		if !s.closed {
+			t.Errorf("expected every sink to be closed")
+		}
+	}
+}
+
+func TestNewSink(t *testing.T) {
+	t.Parallel()
+
+	destDir := t.TempDir()
+	sink, err := NewSink(SinkOptions{
+		Formats: []string{FormatJSONLines, FormatCSV, FormatOpenMetrics},
+		DestDir: destDir,
+	}, "cold")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = sink.Write(context.Background(), newTestRecord("cold", 1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = sink.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"cold", "cold.csv", "cold.prom"} {
+		_, err = os.Stat(filepath.Join(destDir, name))
+		if err != nil {
+			t.Errorf("expected results file %q to exist: %v", name, err)
+		}
+	}
+}
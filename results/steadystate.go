@@ -0,0 +1,34 @@
+package results
+
+// SteadyState describes how long a cluster took to get back to its latency and throughput under load before the measurement.
+type SteadyState struct {
+	BaselineP99LatencyMs float64 `json:"baseline_p99_latency_ms"`
+	BaselineThroughput   float64 `json:"baseline_throughput"`
+	// TolerancePercent is how far from the baseline latency and throughput are still considered steady.
+	TolerancePercent         float64 `json:"tolerance_percent"`
+	TimeToSteadyLatencyMs    int64   `json:"time_to_steady_latency_ms"`
+	TimeToSteadyThroughputMs int64   `json:"time_to_steady_throughput_ms"`
+}
+
+const (
+	TimeToSteadyStateMetric      = "time_to_steady_state_ms"
+	TimeToSteadyLatencyMetric    = "time_to_steady_latency_ms"
+	TimeToSteadyThroughputMetric = "time_to_steady_throughput_ms"
+	BaselineP99LatencyMetric     = "baseline_p99_latency_ms"
+	BaselineThroughputMetric     = "baseline_throughput"
+
+	steadyStateMetricPrefix = "steady_state/"
+)
+
+func SteadyStateMetric(metric string) string {
+	return steadyStateMetricPrefix + metric
+}
+
+func (ss *SteadyState) metrics() []Metric {
+	return []Metric{
+		{Name: SteadyStateMetric(TimeToSteadyLatencyMetric), Value: float64(ss.TimeToSteadyLatencyMs), Unit: UnitMilliseconds},
+		{Name: SteadyStateMetric(TimeToSteadyThroughputMetric), Value: float64(ss.TimeToSteadyThroughputMs), Unit: UnitMilliseconds},
+		{Name: SteadyStateMetric(BaselineP99LatencyMetric), Value: ss.BaselineP99LatencyMs, Unit: UnitMilliseconds},
+		{Name: SteadyStateMetric(BaselineThroughputMetric), Value: ss.BaselineThroughput, Unit: UnitOpsPerSecond, HigherIsBetter: true},
+	}
+}
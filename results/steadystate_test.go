@@ -0,0 +1,34 @@
+package results
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRecordSteadyStateMetrics(t *testing.T) {
+	t.Parallel()
+
+	startTime := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	record := NewRecord(&Metadata{RunID: "run"}, "cold", startTime, startTime.Add(20*time.Second))
+	record.TimeToSteadyStateMs = 35000
+	record.SteadyState = &SteadyState{
+		BaselineP99LatencyMs:     4.5,
+		BaselineThroughput:       100,
+		TolerancePercent:         10,
+		TimeToSteadyLatencyMs:    35000,
+		TimeToSteadyThroughputMs: 25000,
+	}
+
+	expected := []Metric{
+		{Name: "elapsed_time_ms", Value: 20000, Unit: UnitMilliseconds},
+		{Name: "time_to_steady_state_ms", Value: 35000, Unit: UnitMilliseconds},
+		{Name: "steady_state/time_to_steady_latency_ms", Value: 35000, Unit: UnitMilliseconds},
+		{Name: "steady_state/time_to_steady_throughput_ms", Value: 25000, Unit: UnitMilliseconds},
+		{Name: "steady_state/baseline_p99_latency_ms", Value: 4.5, Unit: UnitMilliseconds},
+		{Name: "steady_state/baseline_throughput", Value: 100, Unit: UnitOpsPerSecond, HigherIsBetter: true},
+	}
+	if got := record.Metrics(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected metrics %#v, got %#v", expected, got)
+	}
+}
@@ -0,0 +1,156 @@
+package stats
+
+import (
+	"errors"
+	"math"
+	"slices"
+)
+
+var ErrSamplesTooSmall = errors.New("both samples have to contain at least one value")
+
+// exactMaxSampleProduct is the largest product of the sample sizes for which the p-value is computed from the exact
+// distribution of U. The normal approximation is poor for small samples, e.g. it can't reach a p-value below 0.05
+// with three values on each side.
+const exactMaxSampleProduct = 400
+
+type MannWhitneyUResult struct {
+	U float64
+	// P is the two-sided p-value of the null hypothesis that both samples come from the same distribution.
+	P float64
+}
+
+// MannWhitneyUTest performs a two-sided Mann-Whitney U test. Small samples without ties use the exact distribution of U,
+// others use the normal approximation with tie and continuity corrections.
+func MannWhitneyUTest(x, y []float64) (MannWhitneyUResult, error) {
+	if len(x) == 0 || len(y) == 0 {
+		return MannWhitneyUResult{}, ErrSamplesTooSmall
+	}
+
+	type observation struct {
+		value float64
+		fromX bool
+	}
+
+	observations := make([]observation, 0, len(x)+len(y))
+	for _, v := range x {
+		observations = append(observations, observation{value: v, fromX: true})
+	}
+	for _, v := range y {
+		observations = append(observations, observation{value: v, fromX: false})
+	}
+	slices.SortFunc(observations, func(a, b observation) int {
+		switch {
+		case a.value < b.value:
+			return -1
+		case a.value > b.value:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	var rankSumX, tieCorrection float64
+	for i := 0; i < len(observations); {
+		j := i
+		for j < len(observations) && observations[j].value == observations[i].value {
+			j++
+		}
+
+		// Tied observations share the average of their ranks.
+		rank := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			if observations[k].fromX {
+				rankSumX += rank
+			}
+		}
+
+		t := float64(j - i)
+		tieCorrection += t*t*t - t
+		i = j
+	}
+
+	n1, n2 := float64(len(x)), float64(len(y))
+	n := n1 + n2
+	u1 := rankSumX - n1*(n1+1)/2
+	u := math.Min(u1, n1*n2-u1)
+
+	if tieCorrection == 0 && len(x)*len(y) <= exactMaxSampleProduct {
+		return MannWhitneyUResult{
+			U: u,
+			P: math.Min(1, 2*exactUCDF(len(x), len(y), int(u))),
+		}, nil
+	}
+
+	mean := n1 * n2 / 2
+	variance := n1 * n2 / 12 * ((n + 1) - tieCorrection/(n*(n-1)))
+	if variance <= 0 {
+		// All observations are equal.
+		return MannWhitneyUResult{U: u, P: 1}, nil
+	}
+
+	z := (math.Abs(u-mean) - 0.5) / math.Sqrt(variance)
+	if z < 0 {
+		z = 0
+	}
+
+	return MannWhitneyUResult{
+		U: u,
+		P: math.Min(1, math.Erfc(z/math.Sqrt2)),
+	}, nil
+}
+
+// MannWhitneyUMinP returns the smallest two-sided p-value the test can reach with samples of the sizes, which is
+// when they are completely separated.
+func MannWhitneyUMinP(n1, n2 int) float64 {
+	if n1 == 0 || n2 == 0 {
+		return 1
+	}
+
+	if n1*n2 <= exactMaxSampleProduct {
+		return math.Min(1, 2*exactUCDF(n1, n2, 0))
+	}
+
+	// The normal approximation with the continuity correction at U = 0.
+	mean := float64(n1*n2) / 2
+	variance := float64(n1*n2) * float64(n1+n2+1) / 12
+	z := (mean - 0.5) / math.Sqrt(variance)
+
+	return math.Min(1, math.Erfc(z/math.Sqrt2))
+}
+
+// exactUCDF returns the probability that U of samples of sizes n1 and n2 without ties is at most u, under the null
+// hypothesis that all orderings of the observations are equally likely.
+func exactUCDF(n1, n2 int, u int) float64 {
+	// counts[i][j][k] is the number of orderings of i values of the first sample and j values of the second one
+	// where U is k. The largest value either comes from the first sample, adding j to U, or from the second one.
+	counts := make([][][]float64, n1+1)
+	for i := range counts {
+		counts[i] = make([][]float64, n2+1)
+		for j := range counts[i] {
+			counts[i][j] = make([]float64, i*j+1)
+			if i == 0 || j == 0 {
+				counts[i][j][0] = 1
+				continue
+			}
+
+			for k := range counts[i][j] {
+				if k >= j {
+					counts[i][j][k] += counts[i-1][j][k-j]
+				}
+				if k < len(counts[i][j-1]) {
+					counts[i][j][k] += counts[i][j-1][k]
+				}
+			}
+		}
+	}
+
+	var total, atMostU float64
+	for k, count := range counts[n1][n2] {
+		total += count
+		if k <= u {
+			atMostU += count
+		}
+	}
+
+	return atMostU / total
+}
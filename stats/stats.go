@@ -0,0 +1,103 @@
+package stats
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+)
+
+type Summary struct {
+	N      int
+	Mean   float64
+	StdDev float64
+	Min    float64
+	Max    float64
+	P50    float64
+	P90    float64
+	P95    float64
+	P99    float64
+}
+
+func Summarize(values []float64) Summary {
+	if len(values) == 0 {
+		return Summary{}
+	}
+
+	sorted := slices.Sorted(slices.Values(values))
+
+	return Summary{
+		N:      len(sorted),
+		Mean:   Mean(sorted),
+		StdDev: StdDev(sorted),
+		Min:    sorted[0],
+		Max:    sorted[len(sorted)-1],
+		P50:    Percentile(sorted, 50),
+		P90:    Percentile(sorted, 90),
+		P95:    Percentile(sorted, 95),
+		P99:    Percentile(sorted, 99),
+	}
+}
+
+func Mean(values []float64) float64 {
+	if len(values) == 0 {
+		return math.NaN()
+	}
+
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+
+	return sum / float64(len(values))
+}
+
+// StdDev returns the sample standard deviation.
+func StdDev(values []float64) float64 {
+	if len(values) < 2 {
+		return 0
+	}
+
+	mean := Mean(values)
+	var sum float64
+	for _, v := range values {
+		sum += (v - mean) * (v - mean)
+	}
+
+	return math.Sqrt(sum / float64(len(values)-1))
+}
+
+// Percentile returns the p-th percentile of sorted values, linearly interpolating between the closest ranks.
+func Percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return math.NaN()
+	}
+
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	if lower == upper {
+		return sorted[lower]
+	}
+
+	return sorted[lower] + (rank-float64(lower))*(sorted[upper]-sorted[lower])
+}
+
+// BootstrapMeanCI returns the percentile bootstrap confidence interval of the mean.
+func BootstrapMeanCI(values []float64, confidence float64, iterations int, rng *rand.Rand) (float64, float64) {
+	if len(values) == 0 || iterations <= 0 {
+		return math.NaN(), math.NaN()
+	}
+
+	means := make([]float64, iterations)
+	sample := make([]float64, len(values))
+	for i := range iterations {
+		for j := range sample {
+			sample[j] = values[rng.IntN(len(values))]
+		}
+		means[i] = Mean(sample)
+	}
+	slices.Sort(means)
+
+	alpha := (1 - confidence) / 2
+	return Percentile(means, alpha*100), Percentile(means, (1-alpha)*100)
+}
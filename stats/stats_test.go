@@ -0,0 +1,241 @@
+package stats
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestSummarize(t *testing.T) {
+	t.Parallel()
+
+	s := Summarize([]float64{5, 1, 4, 2, 3})
+
+	if s.N != 5 {
+		t.Errorf("expected n 5, got %d", s.N)
+	}
+	if !almostEqual(s.Mean, 3) {
+		t.Errorf("expected mean 3, got %v", s.Mean)
+	}
+	if !almostEqual(s.StdDev, math.Sqrt(2.5)) {
+		t.Errorf("expected stddev %v, got %v", math.Sqrt(2.5), s.StdDev)
+	}
+	if s.Min != 1 || s.Max != 5 {
+		t.Errorf("expected min 1 and max 5, got %v and %v", s.Min, s.Max)
+	}
+	if !almostEqual(s.P50, 3) {
+		t.Errorf("expected p50 3, got %v", s.P50)
+	}
+	if !almostEqual(s.P90, 4.6) {
+		t.Errorf("expected p90 4.6, got %v", s.P90)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name     string
+		sorted   []float64
+		p        float64
+		expected float64
+	}{
+		{
+			name:     "single value",
+			sorted:   []float64{7},
+			p:        99,
+			expected: 7,
+		},
+		{
+			name:     "exact rank",
+			sorted:   []float64{1, 2, 3},
+			p:        50,
+			expected: 2,
+		},
+		{
+			name:     "interpolated rank",
+			sorted:   []float64{10, 20},
+			p:        25,
+			expected: 12.5,
+		},
+		{
+			name:     "maximum",
+			sorted:   []float64{1, 2, 3, 4},
+			p:        100,
+			expected: 4,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := Percentile(tc.sorted, tc.p)
+			if !almostEqual(got, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestBootstrapMeanCI(t *testing.T) {
+	t.Parallel()
+
+	values := []float64{10, 11, 12, 13, 14, 15, 16, 17, 18, 19}
+	low, high := BootstrapMeanCI(values, 0.95, 2000, rand.New(rand.NewPCG(1, 2)))
+
+	mean := Mean(values)
+	if !(low < mean && mean < high) {
+		t.Errorf("expected interval (%v, %v) to contain the mean %v", low, high, mean)
+	}
+	if low < 10 || high > 19 {
+		t.Errorf("expected interval (%v, %v) to be within the range of values", low, high)
+	}
+}
+
+func TestMannWhitneyUTest(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name        string
+		x           []float64
+		y           []float64
+		expectedU   float64
+		significant bool
+	}{
+		{
+			name:        "separated samples",
+			x:           []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+			y:           []float64{11, 12, 13, 14, 15, 16, 17, 18, 19, 20},
+			expectedU:   0,
+			significant: true,
+		},
+		{
+			name:        "interleaved samples",
+			x:           []float64{1, 3, 5, 7, 9, 11, 13, 15},
+			y:           []float64{2, 4, 6, 8, 10, 12, 14, 16},
+			expectedU:   28,
+			significant: false,
+		},
+		{
+			name:        "separated samples of three values can't be significant",
+			x:           []float64{1, 2, 3},
+			y:           []float64{4, 5, 6},
+			expectedU:   0,
+			significant: false,
+		},
+		{
+			name:        "separated samples of four values are significant",
+			x:           []float64{1, 2, 3, 4},
+			y:           []float64{5, 6, 7, 8},
+			expectedU:   0,
+			significant: true,
+		},
+		{
+			name:        "identical samples",
+			x:           []float64{5, 5, 5},
+			y:           []float64{5, 5, 5},
+			expectedU:   4.5,
+			significant: false,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := MannWhitneyUTest(tc.x, tc.y)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !almostEqual(res.U, tc.expectedU) {
+				t.Errorf("expected U %v, got %v", tc.expectedU, res.U)
+			}
+
+			if significant := res.P < 0.05; significant != tc.significant {
+				t.Errorf("expected significance %v, got p-value %v", tc.significant, res.P)
+			}
+		})
+	}
+
+	_, err := MannWhitneyUTest(nil, []float64{1})
+	if err != ErrSamplesTooSmall {
+		t.Errorf("expected error %v, got %v", ErrSamplesTooSmall, err)
+	}
+}
+
+func TestMannWhitneyUTestExactPValue(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name      string
+		x         []float64
+		y         []float64
+		expectedP float64
+	}{
+		{
+			name:      "separated samples of three values",
+			x:         []float64{1, 2, 3},
+			y:         []float64{4, 5, 6},
+			expectedP: 2.0 / 20,
+		},
+		{
+			name: "samples of three values with one swap",
+			x:    []float64{1, 2, 4},
+			y:    []float64{3, 5, 6},
+			// U is at most 1 in 2 of the 20 orderings.
+			expectedP: 2 * 2.0 / 20,
+		},
+		{
+			name:      "samples of different sizes",
+			x:         []float64{1, 2},
+			y:         []float64{3, 4, 5},
+			expectedP: 2.0 / 10,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := MannWhitneyUTest(tc.x, tc.y)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !almostEqual(res.P, tc.expectedP) {
+				t.Errorf("expected p-value %v, got %v", tc.expectedP, res.P)
+			}
+		})
+	}
+}
+
+func TestMannWhitneyUMinP(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		n1, n2    int
+		expectedP float64
+	}{
+		{n1: 3, n2: 3, expectedP: 2.0 / 20},
+		{n1: 4, n2: 4, expectedP: 2.0 / 70},
+		{n1: 1, n2: 1, expectedP: 1},
+		{n1: 0, n2: 3, expectedP: 1},
+	}
+
+	for _, tc := range tt {
+		if p := MannWhitneyUMinP(tc.n1, tc.n2); !almostEqual(p, tc.expectedP) {
+			t.Errorf("expected the smallest p-value of samples of %d and %d values to be %v, got %v", tc.n1, tc.n2, tc.expectedP, p)
+		}
+	}
+
+	// Large samples use the normal approximation, which can reach any significance level.
+	if p := MannWhitneyUMinP(30, 30); p >= 1e-6 {
+		t.Errorf("expected the smallest p-value of large samples to be tiny, got %v", p)
+	}
+}
@@ -0,0 +1,24 @@
+package stats
+
+import (
+	"math"
+)
+
+// Trend returns the least squares slope of values ordered in time, i.e. the average change between consecutive values.
+func Trend(values []float64) float64 {
+	if len(values) < 2 {
+		return math.NaN()
+	}
+
+	meanX := float64(len(values)-1) / 2
+	meanY := Mean(values)
+
+	var covariance, variance float64
+	for i, v := range values {
+		dx := float64(i) - meanX
+		covariance += dx * (v - meanY)
+		variance += dx * dx
+	}
+
+	return covariance / variance
+}
@@ -0,0 +1,47 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTrend(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name     string
+		values   []float64
+		expected float64
+	}{
+		{
+			name:     "constant",
+			values:   []float64{5, 5, 5, 5},
+			expected: 0,
+		},
+		{
+			name:     "linear growth",
+			values:   []float64{10, 12, 14, 16},
+			expected: 2,
+		},
+		{
+			name:     "noisy decline",
+			values:   []float64{4, 5, 2, 1},
+			expected: -1.2,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := Trend(tc.values)
+			if !almostEqual(got, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+
+	if got := Trend([]float64{1}); !math.IsNaN(got) {
+		t.Errorf("expected NaN for a single value, got %v", got)
+	}
+}
@@ -0,0 +1,57 @@
+package sweep
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// parseList parses a comma-separated list of values and validates them together.
+// Empty values are skipped and the errors of all values that can't be parsed are returned at once.
+func parseList[T any](s string, parse func(string) (T, error), validate func([]T) error) ([]T, error) {
+	var values []T
+	var errs []error
+	for _, valueString := range strings.Split(s, ",") {
+		valueString = strings.TrimSpace(valueString)
+		if len(valueString) == 0 {
+			continue
+		}
+
+		value, err := parse(valueString)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		values = append(values, value)
+	}
+
+	err := errors.Join(errs...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validate(values)
+	if err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// parserOf wraps a parser of a single value, so that its errors name the kind of the value and the value itself.
+func parserOf[T any](kind string, parse func(string) (T, error)) func(string) (T, error) {
+	return func(s string) (T, error) {
+		value, err := parse(s)
+		if err != nil {
+			return value, fmt.Errorf("invalid %s %q: %w", kind, s, err)
+		}
+
+		return value, nil
+	}
+}
+
+// parseString converts a value to a string type without parsing it, leaving the checks to the validation.
+func parseString[T ~string](s string) (T, error) {
+	return T(s), nil
+}
@@ -0,0 +1,93 @@
+package sweep
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+type validationCase[T any] struct {
+	name        string
+	values      []T
+	expectedErr bool
+}
+
+func testValidation[T any](t *testing.T, validate func([]T) error, tt []validationCase[T]) {
+	t.Helper()
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validate(tc.values)
+			if tc.expectedErr && err == nil {
+				t.Errorf("expected an error for %v", tc.values)
+			}
+			if !tc.expectedErr && err != nil {
+				t.Errorf("unexpected error for %v: %v", tc.values, err)
+			}
+		})
+	}
+}
+
+func TestParseList(t *testing.T) {
+	t.Parallel()
+
+	requireValues := func(values []int) error {
+		if len(values) == 0 {
+			return errors.New("at least one value is required")
+		}
+
+		return nil
+	}
+
+	tt := []struct {
+		name        string
+		s           string
+		expected    []int
+		expectedErr string
+	}{
+		{
+			name:     "single value",
+			s:        "1",
+			expected: []int{1},
+		},
+		{
+			name:     "multiple values with spaces and a trailing comma",
+			s:        "1, 2,3,",
+			expected: []int{1, 2, 3},
+		},
+		{
+			name:        "empty list is validated",
+			s:           " , ",
+			expectedErr: "at least one value is required",
+		},
+		{
+			name:        "errors of all values are returned",
+			s:           "one,2,three",
+			expectedErr: "invalid number \"one\": strconv.Atoi: parsing \"one\": invalid syntax\ninvalid number \"three\": strconv.Atoi: parsing \"three\": invalid syntax",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseList(tc.s, parserOf("number", strconv.Atoi), requireValues)
+			if len(tc.expectedErr) != 0 {
+				if err == nil || err.Error() != tc.expectedErr {
+					t.Errorf("expected error %q, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
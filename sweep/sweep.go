@@ -0,0 +1,309 @@
+package sweep
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gocql/gocql"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/yaml"
+)
+
+// PoolSize is the size of a ScyllaDBDatacenterPool used in a scenario.
+type PoolSize struct {
+	Capacity int32 `json:"capacity"`
+	Limit    int32 `json:"limit"`
+}
+
+func (ps PoolSize) String() string {
+	return fmt.Sprintf("%d:%d", ps.Capacity, ps.Limit)
+}
+
+func (ps PoolSize) Validate() error {
+	var errs []error
+
+	if ps.Capacity < 0 {
+		errs = append(errs, fmt.Errorf("capacity must not be negative, got %d", ps.Capacity))
+	}
+
+	if ps.Limit < ps.Capacity {
+		errs = append(errs, fmt.Errorf("limit must not be less than capacity %d, got %d", ps.Capacity, ps.Limit))
+	}
+
+	return errors.Join(errs...)
+}
+
+// ResultsName returns the name of the results of scenarios using the pool size.
+// The pool sizes measured before the sweep was introduced keep their original names,
+// so that their results stay comparable with the existing ones.
+func (ps PoolSize) ResultsName() string {
+	switch ps {
+	case PoolSize{Capacity: 0, Limit: 0}:
+		return "cold"
+	case PoolSize{Capacity: 1, Limit: 1}:
+		return "prewarmed"
+	default:
+		return fmt.Sprintf("capacity-%d-limit-%d", ps.Capacity, ps.Limit)
+	}
+}
+
+// ParsePoolSizes parses a comma-separated list of CAPACITY:LIMIT pairs.
+func ParsePoolSizes(s string) ([]PoolSize, error) {
+	return parseList(s, parserOf("pool size", parsePoolSize), validatePoolSizes)
+}
+
+func parsePoolSize(s string) (PoolSize, error) {
+	capacityString, limitString, ok := strings.Cut(s, ":")
+	if !ok {
+		return PoolSize{}, fmt.Errorf("expected CAPACITY:LIMIT")
+	}
+
+	capacity, err := strconv.ParseInt(capacityString, 10, 32)
+	if err != nil {
+		return PoolSize{}, fmt.Errorf("can't parse capacity: %w", err)
+	}
+
+	limit, err := strconv.ParseInt(limitString, 10, 32)
+	if err != nil {
+		return PoolSize{}, fmt.Errorf("can't parse limit: %w", err)
+	}
+
+	return PoolSize{
+		Capacity: int32(capacity),
+		Limit:    int32(limit),
+	}, nil
+}
+
+func validatePoolSizes(poolSizes []PoolSize) error {
+	var errs []error
+
+	if len(poolSizes) == 0 {
+		errs = append(errs, fmt.Errorf("at least one pool size is required"))
+	}
+
+	seen := map[PoolSize]bool{}
+	for _, ps := range poolSizes {
+		err := ps.Validate()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid pool size %q: %w", ps, err))
+		}
+
+		if seen[ps] {
+			errs = append(errs, fmt.Errorf("duplicate pool size %q", ps))
+		}
+		seen[ps] = true
+	}
+
+	return errors.Join(errs...)
+}
+
+// DataSizeResultsSuffix returns the suffix of the results of scenarios preloading the given amount of data.
+// Scenarios without preloaded data have no suffix, so that their results stay comparable with the existing ones.
+func DataSizeResultsSuffix(size resource.Quantity) string {
+	if size.IsZero() {
+		return ""
+	}
+
+	return fmt.Sprintf("-preload-%s", size.String())
+}
+
+// ParseDataSizes parses a comma-separated list of data sizes, e.g. "0,1Gi,10Gi".
+func ParseDataSizes(s string) ([]resource.Quantity, error) {
+	return parseList(s, parserOf("data size", resource.ParseQuantity), validateDataSizes)
+}
+
+func validateDataSizes(dataSizes []resource.Quantity) error {
+	var errs []error
+
+	if len(dataSizes) == 0 {
+		errs = append(errs, fmt.Errorf("at least one data size is required"))
+	}
+
+	seen := map[int64]bool{}
+	for _, size := range dataSizes {
+		if size.Sign() < 0 {
+			errs = append(errs, fmt.Errorf("data size must not be negative, got %q", size.String()))
+		}
+
+		if seen[size.Value()] {
+			errs = append(errs, fmt.Errorf("duplicate data size %q", size.String()))
+		}
+		seen[size.Value()] = true
+	}
+
+	return errors.Join(errs...)
+}
+
+// ReplicationFactorResultsSuffix returns the suffix of the results of scenarios using the replication factor.
+// Zero stands for a replica on every node, which scenarios used before the replication factor could be set,
+// so it has no suffix.
+func ReplicationFactorResultsSuffix(replicationFactor int) string {
+	if replicationFactor == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("-rf-%d", replicationFactor)
+}
+
+// ParseReplicationFactors parses a comma-separated list of replication factors, e.g. "0,1,3".
+func ParseReplicationFactors(s string) ([]int, error) {
+	return parseList(s, parserOf("replication factor", strconv.Atoi), validateReplicationFactors)
+}
+
+func validateReplicationFactors(replicationFactors []int) error {
+	var errs []error
+
+	if len(replicationFactors) == 0 {
+		errs = append(errs, fmt.Errorf("at least one replication factor is required"))
+	}
+
+	seen := map[int]bool{}
+	for _, rf := range replicationFactors {
+		if rf < 0 {
+			errs = append(errs, fmt.Errorf("replication factor must not be negative, got %d", rf))
+		}
+
+		if seen[rf] {
+			errs = append(errs, fmt.Errorf("duplicate replication factor %d", rf))
+		}
+		seen[rf] = true
+	}
+
+	return errors.Join(errs...)
+}
+
+// ValidateReplicationFactorsForNodes checks that every replication factor can be satisfied by a datacenter
+// with the given number of nodes.
+func ValidateReplicationFactorsForNodes(replicationFactors []int, nodes int) error {
+	var errs []error
+	for _, rf := range replicationFactors {
+		if rf > nodes {
+			errs = append(errs, fmt.Errorf("replication factor %d can't be greater than the number of nodes in the datacenter %d", rf, nodes))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ConsistencyResultsSuffix returns the suffix of the results of scenarios using the consistency level.
+// Quorum was the only consistency level before it could be set, so it has no suffix.
+func ConsistencyResultsSuffix(consistency gocql.Consistency) string {
+	if consistency == gocql.Quorum {
+		return ""
+	}
+
+	return fmt.Sprintf("-cl-%s", strings.ReplaceAll(strings.ToLower(consistency.String()), "_", "-"))
+}
+
+// ParseConsistencies parses a comma-separated list of consistency levels, e.g. "ONE,QUORUM,ALL".
+func ParseConsistencies(s string) ([]gocql.Consistency, error) {
+	return parseList(s, parserOf("consistency level", gocql.ParseConsistencyWrapper), validateConsistencies)
+}
+
+func validateConsistencies(consistencies []gocql.Consistency) error {
+	var errs []error
+
+	if len(consistencies) == 0 {
+		errs = append(errs, fmt.Errorf("at least one consistency level is required"))
+	}
+
+	seen := map[gocql.Consistency]bool{}
+	for _, consistency := range consistencies {
+		// Verification traffic both reads and writes, which ANY and serial consistency levels don't support.
+		if consistency == gocql.Any || consistency.IsSerial() {
+			errs = append(errs, fmt.Errorf("consistency level %v can't be used for both reads and writes", consistency))
+		}
+
+		if seen[consistency] {
+			errs = append(errs, fmt.Errorf("duplicate consistency level %v", consistency))
+		}
+		seen[consistency] = true
+	}
+
+	return errors.Join(errs...)
+}
+
+// Config describes the scenarios to sweep through.
+type Config struct {
+	PoolSizes          []PoolSize          `json:"poolSizes,omitempty"`
+	PreloadSizes       []resource.Quantity `json:"preloadSizes,omitempty"`
+	ReplicationFactors []int               `json:"replicationFactors,omitempty"`
+	Consistencies      []gocql.Consistency `json:"consistencies,omitempty"`
+
+	VolumeSizes       []resource.Quantity                 `json:"volumeSizes,omitempty"`
+	FSTypes           []string                            `json:"fsTypes,omitempty"`
+	VolumeAccessModes []corev1.PersistentVolumeAccessMode `json:"volumeAccessModes,omitempty"`
+	VolumeModes       []corev1.PersistentVolumeMode       `json:"volumeModes,omitempty"`
+	VolumeCounts      []int                               `json:"volumeCounts,omitempty"`
+	PodDensities      []int                               `json:"podDensities,omitempty"`
+}
+
+func (c *Config) Validate() error {
+	var errs []error
+
+	if c.PoolSizes != nil {
+		errs = append(errs, validatePoolSizes(c.PoolSizes))
+	}
+
+	if c.PreloadSizes != nil {
+		errs = append(errs, validateDataSizes(c.PreloadSizes))
+	}
+
+	if c.ReplicationFactors != nil {
+		errs = append(errs, validateReplicationFactors(c.ReplicationFactors))
+	}
+
+	if c.Consistencies != nil {
+		errs = append(errs, validateConsistencies(c.Consistencies))
+	}
+
+	if c.VolumeSizes != nil {
+		errs = append(errs, validateVolumeSizes(c.VolumeSizes))
+	}
+
+	if c.FSTypes != nil {
+		errs = append(errs, validateFSTypes(c.FSTypes))
+	}
+
+	if c.VolumeAccessModes != nil {
+		errs = append(errs, validateVolumeAccessModes(c.VolumeAccessModes))
+	}
+
+	if c.VolumeModes != nil {
+		errs = append(errs, validateVolumeModes(c.VolumeModes))
+	}
+
+	if c.VolumeCounts != nil {
+		errs = append(errs, validateVolumeCounts(c.VolumeCounts))
+	}
+
+	if c.PodDensities != nil {
+		errs = append(errs, validatePodDensities(c.PodDensities))
+	}
+
+	return errors.Join(errs...)
+}
+
+func ReadConfigFile(filePath string) (*Config, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("can't read sweep config file %q: %w", filePath, err)
+	}
+
+	config := &Config{}
+	err = yaml.UnmarshalStrict(data, config)
+	if err != nil {
+		return nil, fmt.Errorf("can't decode sweep config file %q: %w", filePath, err)
+	}
+
+	err = config.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid sweep config file %q: %w", filePath, err)
+	}
+
+	return config, nil
+}
@@ -0,0 +1,302 @@
+package sweep
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/gocql/gocql"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestParsePoolSize(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name        string
+		s           string
+		expected    PoolSize
+		expectedErr bool
+	}{
+		{
+			name:     "capacity and limit",
+			s:        "2:4",
+			expected: PoolSize{Capacity: 2, Limit: 4},
+		},
+		{
+			name:        "missing limit",
+			s:           "1",
+			expectedErr: true,
+		},
+		{
+			name:        "not a number",
+			s:           "one:1",
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parsePoolSize(tc.s)
+			if tc.expectedErr {
+				if err == nil {
+					t.Errorf("expected an error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestValidatePoolSizes(t *testing.T) {
+	t.Parallel()
+
+	testValidation(t, validatePoolSizes, []validationCase[PoolSize]{
+		{name: "multiple pool sizes", values: []PoolSize{{Capacity: 1, Limit: 1}, {Capacity: 0, Limit: 0}, {Capacity: 2, Limit: 4}}},
+		{name: "no pool sizes", values: nil, expectedErr: true},
+		{name: "limit less than capacity", values: []PoolSize{{Capacity: 2, Limit: 1}}, expectedErr: true},
+		{name: "negative capacity", values: []PoolSize{{Capacity: -1, Limit: 1}}, expectedErr: true},
+		{name: "duplicate pool sizes", values: []PoolSize{{Capacity: 1, Limit: 1}, {Capacity: 1, Limit: 1}}, expectedErr: true},
+	})
+}
+
+func TestPoolSizeResultsName(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		poolSize PoolSize
+		expected string
+	}{
+		{poolSize: PoolSize{Capacity: 0, Limit: 0}, expected: "cold"},
+		{poolSize: PoolSize{Capacity: 1, Limit: 1}, expected: "prewarmed"},
+		{poolSize: PoolSize{Capacity: 1, Limit: 2}, expected: "capacity-1-limit-2"},
+		{poolSize: PoolSize{Capacity: 0, Limit: 3}, expected: "capacity-0-limit-3"},
+	}
+
+	for _, tc := range tt {
+		if got := tc.poolSize.ResultsName(); got != tc.expected {
+			t.Errorf("expected results name of %v to be %q, got %q", tc.poolSize, tc.expected, got)
+		}
+	}
+}
+
+func quantityValues(quantities []resource.Quantity) []int64 {
+	var values []int64
+	for _, q := range quantities {
+		values = append(values, q.Value())
+	}
+
+	return values
+}
+
+func TestValidateDataSizes(t *testing.T) {
+	t.Parallel()
+
+	testValidation(t, validateDataSizes, []validationCase[resource.Quantity]{
+		{name: "no data", values: []resource.Quantity{resource.MustParse("0")}},
+		{name: "multiple sizes", values: []resource.Quantity{resource.MustParse("0"), resource.MustParse("1Gi"), resource.MustParse("500M")}},
+		{name: "no sizes", values: nil, expectedErr: true},
+		{name: "negative", values: []resource.Quantity{resource.MustParse("-1Gi")}, expectedErr: true},
+		{name: "duplicate sizes in different units", values: []resource.Quantity{resource.MustParse("1Gi"), resource.MustParse("1024Mi")}, expectedErr: true},
+	})
+}
+
+func TestDataSizeResultsSuffix(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		size     string
+		expected string
+	}{
+		{size: "0", expected: ""},
+		{size: "1Gi", expected: "-preload-1Gi"},
+		{size: "500M", expected: "-preload-500M"},
+	}
+
+	for _, tc := range tt {
+		if got := DataSizeResultsSuffix(resource.MustParse(tc.size)); got != tc.expected {
+			t.Errorf("expected results suffix of %q to be %q, got %q", tc.size, tc.expected, got)
+		}
+	}
+}
+
+func TestValidateReplicationFactors(t *testing.T) {
+	t.Parallel()
+
+	testValidation(t, validateReplicationFactors, []validationCase[int]{
+		{name: "replica on every node", values: []int{0}},
+		{name: "multiple replication factors", values: []int{0, 1, 3}},
+		{name: "no replication factors", values: nil, expectedErr: true},
+		{name: "negative", values: []int{-1}, expectedErr: true},
+		{name: "duplicate replication factors", values: []int{3, 3}, expectedErr: true},
+	})
+}
+
+func TestValidateReplicationFactorsForNodes(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name               string
+		replicationFactors []int
+		nodes              int
+		expectedErr        bool
+	}{
+		{
+			name:               "replica on every node",
+			replicationFactors: []int{0},
+			nodes:              1,
+		},
+		{
+			name:               "replication factor equal to the number of nodes",
+			replicationFactors: []int{1, 3},
+			nodes:              3,
+		},
+		{
+			name:               "replication factor greater than the number of nodes",
+			replicationFactors: []int{1, 3},
+			nodes:              1,
+			expectedErr:        true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateReplicationFactorsForNodes(tc.replicationFactors, tc.nodes)
+			if tc.expectedErr != (err != nil) {
+				t.Errorf("expected error: %v, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateConsistencies(t *testing.T) {
+	t.Parallel()
+
+	testValidation(t, validateConsistencies, []validationCase[gocql.Consistency]{
+		{name: "multiple consistency levels", values: []gocql.Consistency{gocql.One, gocql.Quorum, gocql.All, gocql.LocalQuorum}},
+		{name: "no consistency levels", values: nil, expectedErr: true},
+		{name: "consistency level for writes only", values: []gocql.Consistency{gocql.Any}, expectedErr: true},
+		{name: "serial consistency level", values: []gocql.Consistency{gocql.Serial}, expectedErr: true},
+		{name: "duplicate consistency levels", values: []gocql.Consistency{gocql.One, gocql.One}, expectedErr: true},
+	})
+}
+
+func TestMatrixResultsSuffixes(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name     string
+		got      string
+		expected string
+	}{
+		{name: "replica on every node", got: ReplicationFactorResultsSuffix(0), expected: ""},
+		{name: "replication factor", got: ReplicationFactorResultsSuffix(3), expected: "-rf-3"},
+		{name: "quorum", got: ConsistencyResultsSuffix(gocql.Quorum), expected: ""},
+		{name: "one", got: ConsistencyResultsSuffix(gocql.One), expected: "-cl-one"},
+		{name: "local quorum", got: ConsistencyResultsSuffix(gocql.LocalQuorum), expected: "-cl-local-quorum"},
+	}
+
+	for _, tc := range tt {
+		if tc.got != tc.expected {
+			t.Errorf("expected results suffix for %s to be %q, got %q", tc.name, tc.expected, tc.got)
+		}
+	}
+}
+
+func TestReadConfigFile(t *testing.T) {
+	t.Parallel()
+
+	config, err := ReadConfigFile(filepath.Join("testdata", "config.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedPoolSizes := []PoolSize{{Capacity: 0, Limit: 0}, {Capacity: 1, Limit: 2}, {Capacity: 3, Limit: 3}}
+	if !reflect.DeepEqual(config.PoolSizes, expectedPoolSizes) {
+		t.Errorf("expected pool sizes %v, got %v", expectedPoolSizes, config.PoolSizes)
+	}
+
+	expectedPreloadSizes := []int64{0, 1 << 30, 10 << 30}
+	if got := quantityValues(config.PreloadSizes); !reflect.DeepEqual(got, expectedPreloadSizes) {
+		t.Errorf("expected preload sizes %v, got %v", expectedPreloadSizes, got)
+	}
+
+	expectedReplicationFactors := []int{1, 3}
+	if !reflect.DeepEqual(config.ReplicationFactors, expectedReplicationFactors) {
+		t.Errorf("expected replication factors %v, got %v", expectedReplicationFactors, config.ReplicationFactors)
+	}
+
+	expectedConsistencies := []gocql.Consistency{gocql.One, gocql.Quorum, gocql.All, gocql.LocalQuorum}
+	if !reflect.DeepEqual(config.Consistencies, expectedConsistencies) {
+		t.Errorf("expected consistency levels %v, got %v", expectedConsistencies, config.Consistencies)
+	}
+
+	expectedVolumeSizes := []int64{100 << 20, 10 << 30}
+	if got := quantityValues(config.VolumeSizes); !reflect.DeepEqual(got, expectedVolumeSizes) {
+		t.Errorf("expected volume sizes %v, got %v", expectedVolumeSizes, got)
+	}
+
+	expectedFSTypes := []string{"xfs", "ext4"}
+	if !reflect.DeepEqual(config.FSTypes, expectedFSTypes) {
+		t.Errorf("expected filesystem types %v, got %v", expectedFSTypes, config.FSTypes)
+	}
+
+	expectedVolumeAccessModes := []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce, corev1.ReadWriteOncePod}
+	if !reflect.DeepEqual(config.VolumeAccessModes, expectedVolumeAccessModes) {
+		t.Errorf("expected volume access modes %v, got %v", expectedVolumeAccessModes, config.VolumeAccessModes)
+	}
+
+	expectedVolumeModes := []corev1.PersistentVolumeMode{corev1.PersistentVolumeFilesystem, corev1.PersistentVolumeBlock}
+	if !reflect.DeepEqual(config.VolumeModes, expectedVolumeModes) {
+		t.Errorf("expected volume modes %v, got %v", expectedVolumeModes, config.VolumeModes)
+	}
+
+	expectedVolumeCounts := []int{1, 2, 4}
+	if !reflect.DeepEqual(config.VolumeCounts, expectedVolumeCounts) {
+		t.Errorf("expected volume counts %v, got %v", expectedVolumeCounts, config.VolumeCounts)
+	}
+
+	expectedPodDensities := []int{1, 10, 50}
+	if !reflect.DeepEqual(config.PodDensities, expectedPodDensities) {
+		t.Errorf("expected pod densities %v, got %v", expectedPodDensities, config.PodDensities)
+	}
+
+	for _, content := range []string{
+		"poolSize:\n- capacity: 1\n  limit: 1\n",
+		"poolSizes:\n- capacity: 2\n  limit: 1\n",
+		"preloadSizes:\n- 1Gi\n- 1024Mi\n",
+		"replicationFactors:\n- -1\n",
+		"consistencies:\n- MOST\n",
+		"consistencies:\n- SERIAL\n",
+		"volumeSizes:\n- \"0\"\n",
+		"fsTypes:\n- btrfs\n",
+		"volumeAccessModes:\n- ReadWriteMany\n",
+		"volumeModes:\n- Raw\n",
+		"volumeCounts:\n- 0\n",
+		"podDensities:\n- 10\n- 10\n",
+	} {
+		filePath := filepath.Join(t.TempDir(), "config.yaml")
+		err = os.WriteFile(filePath, []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = ReadConfigFile(filePath)
+		if err == nil {
+			t.Errorf("expected an error for config %q", content)
+		}
+	}
+}
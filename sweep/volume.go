@@ -0,0 +1,271 @@
+package sweep
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	DefaultVolumeSize       = "100Mi"
+	DefaultFSType           = "xfs"
+	DefaultVolumeAccessMode = corev1.ReadWriteOnce
+	DefaultVolumeMode       = corev1.PersistentVolumeFilesystem
+)
+
+var (
+	SupportedFSTypes = []string{
+		"xfs",
+		"ext4",
+	}
+
+	// SupportedVolumeAccessModes are the access modes supported by the block storage backends.
+	SupportedVolumeAccessModes = []corev1.PersistentVolumeAccessMode{
+		corev1.ReadWriteOnce,
+		corev1.ReadWriteOncePod,
+	}
+
+	SupportedVolumeModes = []corev1.PersistentVolumeMode{
+		corev1.PersistentVolumeFilesystem,
+		corev1.PersistentVolumeBlock,
+	}
+
+	volumeAccessModeAbbreviations = map[corev1.PersistentVolumeAccessMode]string{
+		corev1.ReadWriteOnce:    "rwo",
+		corev1.ReadWriteOncePod: "rwop",
+	}
+)
+
+// Volume describes the backend volume used in a scenario.
+type Volume struct {
+	Size resource.Quantity
+	// FSType is empty for block volumes.
+	FSType     string
+	AccessMode corev1.PersistentVolumeAccessMode
+	Mode       corev1.PersistentVolumeMode
+}
+
+func (v Volume) String() string {
+	if v.Mode == corev1.PersistentVolumeBlock {
+		return fmt.Sprintf("%s block %s", v.Size.String(), v.AccessMode)
+	}
+
+	return fmt.Sprintf("%s %s %s", v.Size.String(), v.FSType, v.AccessMode)
+}
+
+// ResultsSuffix returns the suffix of the results of scenarios using the volume.
+// Only the parameters that differ from the defaults are included, so that the results of the volume used before
+// the matrix was introduced stay comparable with the existing ones.
+func (v Volume) ResultsSuffix() string {
+	var sb strings.Builder
+
+	if v.Size.Cmp(resource.MustParse(DefaultVolumeSize)) != 0 {
+		fmt.Fprintf(&sb, "-size-%s", v.Size.String())
+	}
+
+	if v.Mode == corev1.PersistentVolumeBlock {
+		sb.WriteString("-block")
+	} else if v.FSType != DefaultFSType {
+		fmt.Fprintf(&sb, "-%s", v.FSType)
+	}
+
+	if v.AccessMode != DefaultVolumeAccessMode {
+		fmt.Fprintf(&sb, "-%s", volumeAccessModeAbbreviations[v.AccessMode])
+	}
+
+	return sb.String()
+}
+
+// VolumeMatrix returns all combinations of the volume parameters.
+// Block volumes have no filesystem, so they are only combined with the remaining parameters.
+func VolumeMatrix(sizes []resource.Quantity, fsTypes []string, accessModes []corev1.PersistentVolumeAccessMode, modes []corev1.PersistentVolumeMode) []Volume {
+	var volumes []Volume
+	for _, mode := range modes {
+		modeFSTypes := fsTypes
+		if mode == corev1.PersistentVolumeBlock {
+			modeFSTypes = []string{""}
+		}
+
+		for _, size := range sizes {
+			for _, fsType := range modeFSTypes {
+				for _, accessMode := range accessModes {
+					volumes = append(volumes, Volume{
+						Size:       size,
+						FSType:     fsType,
+						AccessMode: accessMode,
+						Mode:       mode,
+					})
+				}
+			}
+		}
+	}
+
+	return volumes
+}
+
+// ParseVolumeSizes parses a comma-separated list of volume sizes, e.g. "100Mi,10Gi".
+func ParseVolumeSizes(s string) ([]resource.Quantity, error) {
+	return parseList(s, parserOf("volume size", resource.ParseQuantity), validateVolumeSizes)
+}
+
+func validateVolumeSizes(sizes []resource.Quantity) error {
+	var errs []error
+
+	if len(sizes) == 0 {
+		errs = append(errs, fmt.Errorf("at least one volume size is required"))
+	}
+
+	seen := map[int64]bool{}
+	for _, size := range sizes {
+		if size.Sign() <= 0 {
+			errs = append(errs, fmt.Errorf("volume size must be greater than zero, got %q", size.String()))
+		}
+
+		if seen[size.Value()] {
+			errs = append(errs, fmt.Errorf("duplicate volume size %q", size.String()))
+		}
+		seen[size.Value()] = true
+	}
+
+	return errors.Join(errs...)
+}
+
+// ParseFSTypes parses a comma-separated list of filesystem types, e.g. "xfs,ext4".
+func ParseFSTypes(s string) ([]string, error) {
+	return parseList(s, parseString[string], validateFSTypes)
+}
+
+func validateFSTypes(fsTypes []string) error {
+	var errs []error
+
+	if len(fsTypes) == 0 {
+		errs = append(errs, fmt.Errorf("at least one filesystem type is required"))
+	}
+
+	seen := map[string]bool{}
+	for _, fsType := range fsTypes {
+		if !slices.Contains(SupportedFSTypes, fsType) {
+			errs = append(errs, fmt.Errorf("unsupported filesystem type %q, supported types are: %v", fsType, SupportedFSTypes))
+		}
+
+		if seen[fsType] {
+			errs = append(errs, fmt.Errorf("duplicate filesystem type %q", fsType))
+		}
+		seen[fsType] = true
+	}
+
+	return errors.Join(errs...)
+}
+
+// ParseVolumeAccessModes parses a comma-separated list of access modes, e.g. "ReadWriteOnce,ReadWriteOncePod".
+func ParseVolumeAccessModes(s string) ([]corev1.PersistentVolumeAccessMode, error) {
+	return parseList(s, parseString[corev1.PersistentVolumeAccessMode], validateVolumeAccessModes)
+}
+
+func validateVolumeAccessModes(accessModes []corev1.PersistentVolumeAccessMode) error {
+	var errs []error
+
+	if len(accessModes) == 0 {
+		errs = append(errs, fmt.Errorf("at least one access mode is required"))
+	}
+
+	seen := map[corev1.PersistentVolumeAccessMode]bool{}
+	for _, accessMode := range accessModes {
+		if !slices.Contains(SupportedVolumeAccessModes, accessMode) {
+			errs = append(errs, fmt.Errorf("unsupported access mode %q, supported access modes are: %v", accessMode, SupportedVolumeAccessModes))
+		}
+
+		if seen[accessMode] {
+			errs = append(errs, fmt.Errorf("duplicate access mode %q", accessMode))
+		}
+		seen[accessMode] = true
+	}
+
+	return errors.Join(errs...)
+}
+
+// ParseVolumeModes parses a comma-separated list of volume modes, e.g. "Filesystem,Block".
+func ParseVolumeModes(s string) ([]corev1.PersistentVolumeMode, error) {
+	return parseList(s, parseString[corev1.PersistentVolumeMode], validateVolumeModes)
+}
+
+func validateVolumeModes(modes []corev1.PersistentVolumeMode) error {
+	var errs []error
+
+	if len(modes) == 0 {
+		errs = append(errs, fmt.Errorf("at least one volume mode is required"))
+	}
+
+	seen := map[corev1.PersistentVolumeMode]bool{}
+	for _, mode := range modes {
+		if !slices.Contains(SupportedVolumeModes, mode) {
+			errs = append(errs, fmt.Errorf("unsupported volume mode %q, supported volume modes are: %v", mode, SupportedVolumeModes))
+		}
+
+		if seen[mode] {
+			errs = append(errs, fmt.Errorf("duplicate volume mode %q", mode))
+		}
+		seen[mode] = true
+	}
+
+	return errors.Join(errs...)
+}
+
+// ParseVolumeCounts parses a comma-separated list of numbers of volumes of a pod, e.g. "1,2,4".
+func ParseVolumeCounts(s string) ([]int, error) {
+	return parseList(s, parserOf("volume count", strconv.Atoi), validateVolumeCounts)
+}
+
+func validateVolumeCounts(counts []int) error {
+	var errs []error
+
+	if len(counts) == 0 {
+		errs = append(errs, fmt.Errorf("at least one volume count is required"))
+	}
+
+	seen := map[int]bool{}
+	for _, count := range counts {
+		if count <= 0 {
+			errs = append(errs, fmt.Errorf("volume count must be greater than zero, got %d", count))
+		}
+
+		if seen[count] {
+			errs = append(errs, fmt.Errorf("duplicate volume count %d", count))
+		}
+		seen[count] = true
+	}
+
+	return errors.Join(errs...)
+}
+
+// ParsePodDensities parses a comma-separated list of numbers of pods packed onto a single node, e.g. "1,10,50".
+func ParsePodDensities(s string) ([]int, error) {
+	return parseList(s, parserOf("pod density", strconv.Atoi), validatePodDensities)
+}
+
+func validatePodDensities(densities []int) error {
+	var errs []error
+
+	if len(densities) == 0 {
+		errs = append(errs, fmt.Errorf("at least one pod density is required"))
+	}
+
+	seen := map[int]bool{}
+	for _, density := range densities {
+		if density <= 0 {
+			errs = append(errs, fmt.Errorf("pod density must be greater than zero, got %d", density))
+		}
+
+		if seen[density] {
+			errs = append(errs, fmt.Errorf("duplicate pod density %d", density))
+		}
+		seen[density] = true
+	}
+
+	return errors.Join(errs...)
+}
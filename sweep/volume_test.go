@@ -0,0 +1,124 @@
+package sweep
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestValidateVolumeSizes(t *testing.T) {
+	t.Parallel()
+
+	testValidation(t, validateVolumeSizes, []validationCase[resource.Quantity]{
+		{name: "multiple sizes", values: []resource.Quantity{resource.MustParse("100Mi"), resource.MustParse("10Gi")}},
+		{name: "no sizes", values: nil, expectedErr: true},
+		{name: "zero size", values: []resource.Quantity{resource.MustParse("0")}, expectedErr: true},
+		{name: "duplicate sizes in different units", values: []resource.Quantity{resource.MustParse("1Gi"), resource.MustParse("1024Mi")}, expectedErr: true},
+	})
+}
+
+func TestValidateFSTypes(t *testing.T) {
+	t.Parallel()
+
+	testValidation(t, validateFSTypes, []validationCase[string]{
+		{name: "multiple filesystem types", values: []string{"xfs", "ext4"}},
+		{name: "no filesystem types", values: nil, expectedErr: true},
+		{name: "unsupported filesystem type", values: []string{"btrfs"}, expectedErr: true},
+		{name: "duplicate filesystem types", values: []string{"ext4", "ext4"}, expectedErr: true},
+	})
+}
+
+func TestValidateVolumeAccessModes(t *testing.T) {
+	t.Parallel()
+
+	testValidation(t, validateVolumeAccessModes, []validationCase[corev1.PersistentVolumeAccessMode]{
+		{name: "multiple access modes", values: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce, corev1.ReadWriteOncePod}},
+		{name: "no access modes", values: nil, expectedErr: true},
+		{name: "access mode unsupported by block storage", values: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}, expectedErr: true},
+		{name: "duplicate access modes", values: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce, corev1.ReadWriteOnce}, expectedErr: true},
+	})
+}
+
+func TestValidateVolumeModes(t *testing.T) {
+	t.Parallel()
+
+	testValidation(t, validateVolumeModes, []validationCase[corev1.PersistentVolumeMode]{
+		{name: "multiple volume modes", values: []corev1.PersistentVolumeMode{corev1.PersistentVolumeFilesystem, corev1.PersistentVolumeBlock}},
+		{name: "no volume modes", values: nil, expectedErr: true},
+		{name: "unknown volume mode", values: []corev1.PersistentVolumeMode{"block"}, expectedErr: true},
+		{name: "duplicate volume modes", values: []corev1.PersistentVolumeMode{corev1.PersistentVolumeBlock, corev1.PersistentVolumeBlock}, expectedErr: true},
+	})
+}
+
+func TestValidateVolumeCounts(t *testing.T) {
+	t.Parallel()
+
+	testValidation(t, validateVolumeCounts, []validationCase[int]{
+		{name: "multiple volume counts", values: []int{1, 2, 4}},
+		{name: "no volume counts", values: nil, expectedErr: true},
+		{name: "zero volumes", values: []int{0}, expectedErr: true},
+		{name: "duplicate volume counts", values: []int{2, 2}, expectedErr: true},
+	})
+}
+
+func TestValidatePodDensities(t *testing.T) {
+	t.Parallel()
+
+	testValidation(t, validatePodDensities, []validationCase[int]{
+		{name: "multiple pod densities", values: []int{1, 10, 50}},
+		{name: "no pod densities", values: nil, expectedErr: true},
+		{name: "negative pod density", values: []int{-1}, expectedErr: true},
+		{name: "duplicate pod densities", values: []int{10, 10}, expectedErr: true},
+	})
+}
+
+func TestVolumeMatrix(t *testing.T) {
+	t.Parallel()
+
+	volumes := VolumeMatrix(
+		[]resource.Quantity{resource.MustParse("100Mi"), resource.MustParse("1Gi")},
+		[]string{"xfs", "ext4"},
+		[]corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce, corev1.ReadWriteOncePod},
+		[]corev1.PersistentVolumeMode{corev1.PersistentVolumeFilesystem, corev1.PersistentVolumeBlock},
+	)
+
+	var got []string
+	for _, v := range volumes {
+		got = append(got, v.ResultsSuffix())
+	}
+
+	expected := []string{
+		"",
+		"-rwop",
+		"-ext4",
+		"-ext4-rwop",
+		"-size-1Gi",
+		"-size-1Gi-rwop",
+		"-size-1Gi-ext4",
+		"-size-1Gi-ext4-rwop",
+		"-block",
+		"-block-rwop",
+		"-size-1Gi-block",
+		"-size-1Gi-block-rwop",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected suffixes %q, got %q", expected, got)
+	}
+}
+
+func TestVolumeResultsSuffix(t *testing.T) {
+	t.Parallel()
+
+	// Equal sizes in a different format must keep the legacy results name.
+	v := Volume{
+		Size:       resource.MustParse("104857600"),
+		FSType:     DefaultFSType,
+		AccessMode: DefaultVolumeAccessMode,
+		Mode:       DefaultVolumeMode,
+	}
+	if got := v.ResultsSuffix(); got != "" {
+		t.Errorf("expected an empty suffix, got %q", got)
+	}
+}
@@ -0,0 +1,53 @@
+package timeline
+
+import (
+	"time"
+
+	"github.com/pausing-clusters-thesis/benchmarks/results"
+)
+
+// Stopwatch records the times milestones are reached by a sequence of blocking waits.
+type Stopwatch struct {
+	startTime time.Time
+	phases    []results.Phase
+	lastTime  time.Time
+}
+
+func NewStopwatch(startTime time.Time) *Stopwatch {
+	return &Stopwatch{
+		startTime: startTime,
+		lastTime:  startTime,
+	}
+}
+
+// Lap marks the milestone as reached now and returns the time it was reached.
+func (s *Stopwatch) Lap(name string) time.Time {
+	return s.LapAt(name, time.Now())
+}
+
+func (s *Stopwatch) LapAt(name string, reachedTime time.Time) time.Time {
+	s.phases = append(s.phases, results.Phase{
+		Name:       name,
+		OffsetMs:   reachedTime.Sub(s.startTime).Milliseconds(),
+		DurationMs: max(reachedTime.Sub(s.lastTime), 0).Milliseconds(),
+	})
+
+	if reachedTime.After(s.lastTime) {
+		s.lastTime = reachedTime
+	}
+
+	return reachedTime
+}
+
+func (s *Stopwatch) StartTime() time.Time {
+	return s.startTime
+}
+
+// LastTime returns the time the latest milestone was reached, or the start time if none was.
+func (s *Stopwatch) LastTime() time.Time {
+	return s.lastTime
+}
+
+func (s *Stopwatch) Phases() []results.Phase {
+	return append([]results.Phase(nil), s.phases...)
+}
@@ -0,0 +1,128 @@
+package timeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pausing-clusters-thesis/benchmarks/results"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	DefaultPollInterval = 100 * time.Millisecond
+)
+
+// Milestone is a named point in time at which the observed state first satisfies the condition.
+type Milestone struct {
+	Name      string
+	Condition func(ctx context.Context) (bool, error)
+}
+
+// Tracker polls the conditions of milestones and records the time each of them was first reached.
+type Tracker struct {
+	milestones   []Milestone
+	pollInterval time.Duration
+
+	lock        sync.Mutex
+	reachedTime map[string]time.Time
+	lastErrors  map[string]error
+}
+
+func NewTracker(pollInterval time.Duration, milestones ...Milestone) *Tracker {
+	return &Tracker{
+		milestones:   milestones,
+		pollInterval: pollInterval,
+		reachedTime:  make(map[string]time.Time, len(milestones)),
+		lastErrors:   map[string]error{},
+	}
+}
+
+// Run blocks until all milestones are reached or the context is done.
+// Condition errors are considered transient and are retried until the context is done.
+func (t *Tracker) Run(ctx context.Context) error {
+	err := wait.PollUntilContextCancel(ctx, t.pollInterval, true, func(ctx context.Context) (bool, error) {
+		now := time.Now()
+
+		pending := 0
+		for _, m := range t.milestones {
+			if t.isReached(m.Name) {
+				continue
+			}
+
+			ok, err := m.Condition(ctx)
+			t.lock.Lock()
+			if err != nil {
+				t.lastErrors[m.Name] = err
+			} else {
+				delete(t.lastErrors, m.Name)
+			}
+			if ok {
+				t.reachedTime[m.Name] = now
+			}
+			t.lock.Unlock()
+
+			if !ok {
+				pending++
+			}
+		}
+
+		return pending == 0, nil
+	})
+	if err != nil {
+		return fmt.Errorf("can't observe all milestones: %w", errors.Join(append([]error{err}, t.errors()...)...))
+	}
+
+	return nil
+}
+
+func (t *Tracker) isReached(name string) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	_, ok := t.reachedTime[name]
+	return ok
+}
+
+func (t *Tracker) errors() []error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	var errs []error
+	for name, err := range t.lastErrors {
+		errs = append(errs, fmt.Errorf("milestone %q: %w", name, err))
+	}
+
+	return errs
+}
+
+// Phases converts the reached milestones into phases, in the order the milestones were given.
+// Each phase lasts from the latest preceding milestone, or startTime for the first one, to its own milestone.
+func (t *Tracker) Phases(startTime time.Time) ([]results.Phase, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	phases := make([]results.Phase, 0, len(t.milestones))
+	previousTime := startTime
+	for _, m := range t.milestones {
+		reachedTime, ok := t.reachedTime[m.Name]
+		if !ok {
+			return nil, fmt.Errorf("milestone %q has not been reached", m.Name)
+		}
+
+		duration := max(reachedTime.Sub(previousTime), 0)
+		phases = append(phases, results.Phase{
+			Name:       m.Name,
+			OffsetMs:   reachedTime.Sub(startTime).Milliseconds(),
+			DurationMs: duration.Milliseconds(),
+		})
+
+		if reachedTime.After(previousTime) {
+			previousTime = reachedTime
+		}
+	}
+
+	return phases, nil
+}
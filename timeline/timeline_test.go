@@ -0,0 +1,172 @@
+package timeline
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pausing-clusters-thesis/benchmarks/results"
+)
+
+func TestTracker_Run(t *testing.T) {
+	t.Parallel()
+
+	var polls atomic.Int32
+	tracker := NewTracker(time.Millisecond,
+		Milestone{
+			Name: "first",
+			Condition: func(context.Context) (bool, error) {
+				return true, nil
+			},
+		},
+		Milestone{
+			Name: "second",
+			Condition: func(context.Context) (bool, error) {
+				return polls.Add(1) >= 3, nil
+			},
+		},
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	startTime := time.Now()
+	err := tracker.Run(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	phases, err := tracker.Phases(startTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(phases) != 2 || phases[0].Name != "first" || phases[1].Name != "second" {
+		t.Fatalf("unexpected phases: %#v", phases)
+	}
+
+	if polls.Load() != 3 {
+		t.Errorf("expected condition of the second milestone to be polled 3 times, got %d", polls.Load())
+	}
+}
+
+func TestTracker_RunReturnsConditionErrorsOnTimeout(t *testing.T) {
+	t.Parallel()
+
+	conditionErr := errors.New("condition error")
+	tracker := NewTracker(time.Millisecond, Milestone{
+		Name: "failing",
+		Condition: func(context.Context) (bool, error) {
+			return false, conditionErr
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := tracker.Run(ctx)
+	if !errors.Is(err, conditionErr) {
+		t.Fatalf("expected error wrapping %v, got %v", conditionErr, err)
+	}
+}
+
+func TestTracker_Phases(t *testing.T) {
+	t.Parallel()
+
+	startTime := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		name           string
+		milestones     []string
+		reachedTime    map[string]time.Time
+		expectedPhases []results.Phase
+		expectedErr    bool
+	}{
+		{
+			name:       "sequential milestones",
+			milestones: []string{"a", "b", "c"},
+			reachedTime: map[string]time.Time{
+				"a": startTime.Add(1 * time.Second),
+				"b": startTime.Add(3 * time.Second),
+				"c": startTime.Add(6 * time.Second),
+			},
+			expectedPhases: []results.Phase{
+				{Name: "a", OffsetMs: 1000, DurationMs: 1000},
+				{Name: "b", OffsetMs: 3000, DurationMs: 2000},
+				{Name: "c", OffsetMs: 6000, DurationMs: 3000},
+			},
+		},
+		{
+			name:       "milestone reached before its predecessor has zero duration",
+			milestones: []string{"a", "b", "c"},
+			reachedTime: map[string]time.Time{
+				"a": startTime.Add(2 * time.Second),
+				"b": startTime.Add(1 * time.Second),
+				"c": startTime.Add(5 * time.Second),
+			},
+			expectedPhases: []results.Phase{
+				{Name: "a", OffsetMs: 2000, DurationMs: 2000},
+				{Name: "b", OffsetMs: 1000, DurationMs: 0},
+				{Name: "c", OffsetMs: 5000, DurationMs: 3000},
+			},
+		},
+		{
+			name:       "unreached milestone",
+			milestones: []string{"a", "b"},
+			reachedTime: map[string]time.Time{
+				"a": startTime.Add(time.Second),
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var milestones []Milestone
+			for _, name := range tc.milestones {
+				milestones = append(milestones, Milestone{Name: name})
+			}
+
+			tracker := NewTracker(time.Millisecond, milestones...)
+			tracker.reachedTime = tc.reachedTime
+
+			phases, err := tracker.Phases(startTime)
+			if tc.expectedErr != (err != nil) {
+				t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+			}
+
+			if !reflect.DeepEqual(phases, tc.expectedPhases) {
+				t.Errorf("expected phases %#v, got %#v", tc.expectedPhases, phases)
+			}
+		})
+	}
+}
+
+func TestStopwatch(t *testing.T) {
+	t.Parallel()
+
+	startTime := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	stopwatch := NewStopwatch(startTime)
+	stopwatch.LapAt("first", startTime.Add(2*time.Second))
+	// A milestone observed out of order mustn't produce a negative duration.
+	stopwatch.LapAt("second", startTime.Add(time.Second))
+	stopwatch.LapAt("third", startTime.Add(5*time.Second))
+
+	expected := []results.Phase{
+		{Name: "first", OffsetMs: 2000, DurationMs: 2000},
+		{Name: "second", OffsetMs: 1000, DurationMs: 0},
+		{Name: "third", OffsetMs: 5000, DurationMs: 3000},
+	}
+	if phases := stopwatch.Phases(); !reflect.DeepEqual(phases, expected) {
+		t.Errorf("expected phases %#v, got %#v", expected, phases)
+	}
+
+	if !stopwatch.LastTime().Equal(startTime.Add(5 * time.Second)) {
+		t.Errorf("expected last time %v, got %v", startTime.Add(5*time.Second), stopwatch.LastTime())
+	}
+}
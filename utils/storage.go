@@ -10,10 +10,25 @@ import (
 	"k8s.io/utils/ptr"
 )
 
+const defaultFSType = "xfs"
+
 func GetImmediateStorageClassForCSIDriver(csiDriverName string) (*storagev1.StorageClass, error) {
+	return GetImmediateStorageClassForCSIDriverWithFSType(csiDriverName, defaultFSType)
+}
+
+// GetImmediateStorageClassForCSIDriverWithFSType returns an immediate StorageClass for the CSI driver with volumes
+// formatted with fsType. An empty fsType leaves the fstype parameter unset, e.g. for block volumes.
+func GetImmediateStorageClassForCSIDriverWithFSType(csiDriverName string, fsType string) (*storagev1.StorageClass, error) {
+	var sc *storagev1.StorageClass
+
 	switch csiDriverName {
 	case naming.LocalCSIDriverName:
-		return &storagev1.StorageClass{
+		// Local CSI driver only supports xfs.
+		if fsType != defaultFSType {
+			return nil, fmt.Errorf("unsupported fstype for CSI Driver %q: %q", csiDriverName, fsType)
+		}
+
+		sc = &storagev1.StorageClass{
 			ObjectMeta: metav1.ObjectMeta{
 				GenerateName: "scylladb-local-xfs-immediate-",
 			},
@@ -23,38 +38,44 @@ func GetImmediateStorageClassForCSIDriver(csiDriverName string) (*storagev1.Stor
 			},
 			ReclaimPolicy:     ptr.To(corev1.PersistentVolumeReclaimDelete),
 			VolumeBindingMode: ptr.To(storagev1.VolumeBindingImmediate),
-		}, nil
+		}
 
 	case naming.GCEPersistentDiskCSIDriverName:
-		return &storagev1.StorageClass{
+		sc = &storagev1.StorageClass{
 			ObjectMeta: metav1.ObjectMeta{
 				GenerateName: "premium-rwo-immediate-",
 			},
 			Provisioner: naming.GCEPersistentDiskCSIDriverName,
 			Parameters: map[string]string{
-				"csi.storage.k8s.io/fstype": "xfs",
+				"csi.storage.k8s.io/fstype": fsType,
 				"type":                      "pd-ssd",
 			},
 			ReclaimPolicy:     ptr.To(corev1.PersistentVolumeReclaimDelete),
 			VolumeBindingMode: ptr.To(storagev1.VolumeBindingImmediate),
-		}, nil
+		}
 
 	case naming.EBSCSIDriverName:
-		return &storagev1.StorageClass{
+		sc = &storagev1.StorageClass{
 			ObjectMeta: metav1.ObjectMeta{
 				GenerateName: "gp3-immediate-",
 			},
 			Provisioner: naming.EBSCSIDriverName,
 			Parameters: map[string]string{
-				"csi.storage.k8s.io/fstype": "xfs",
+				"csi.storage.k8s.io/fstype": fsType,
 				"type":                      "gp3",
 			},
 			ReclaimPolicy:     ptr.To(corev1.PersistentVolumeReclaimDelete),
 			VolumeBindingMode: ptr.To(storagev1.VolumeBindingImmediate),
-		}, nil
+		}
 
 	default:
 		return nil, fmt.Errorf("unsupported CSI Driver name: %q", csiDriverName)
 
 	}
+
+	if len(fsType) == 0 {
+		delete(sc.Parameters, "csi.storage.k8s.io/fstype")
+	}
+
+	return sc, nil
 }
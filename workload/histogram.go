@@ -0,0 +1,155 @@
+package workload
+
+import (
+	"math"
+	"math/bits"
+	"time"
+
+	"github.com/pausing-clusters-thesis/benchmarks/results"
+)
+
+const (
+	// histogramPrecisionBits sets the number of linear sub-buckets in every power of two range of values,
+	// which bounds the relative error of recorded values to 2^-(histogramPrecisionBits-1).
+	histogramPrecisionBits = 8
+	histogramSubBuckets    = 1 << histogramPrecisionBits
+	histogramHalfBuckets   = histogramSubBuckets / 2
+)
+
+// Histogram records latencies in microseconds into log-linear buckets, like an HDR histogram.
+// Values below histogramSubBuckets are recorded exactly and larger ones with a bounded relative error.
+type Histogram struct {
+	counts []int64
+	count  int64
+	sum    int64
+	min    int64
+	max    int64
+}
+
+func NewHistogram() *Histogram {
+	return &Histogram{
+		min: math.MaxInt64,
+	}
+}
+
+func bucketIndex(v int64) int {
+	if v < histogramSubBuckets {
+		return int(v)
+	}
+
+	shift := bits.Len64(uint64(v)) - histogramPrecisionBits
+	return shift*histogramHalfBuckets + int(v>>shift)
+}
+
+// bucketHighestValue returns the highest value recorded into the bucket.
+func bucketHighestValue(idx int) int64 {
+	if idx < histogramSubBuckets {
+		return int64(idx)
+	}
+
+	shift := (idx-histogramSubBuckets)/histogramHalfBuckets + 1
+	subBucket := int64(idx - shift*histogramHalfBuckets)
+	return (subBucket+1)<<shift - 1
+}
+
+func (h *Histogram) RecordValue(v int64) {
+	v = max(v, 0)
+
+	idx := bucketIndex(v)
+	if idx >= len(h.counts) {
+		h.counts = append(h.counts, make([]int64, idx-len(h.counts)+1)...)
+	}
+
+	h.counts[idx]++
+	h.count++
+	h.sum += v
+	h.min = min(h.min, v)
+	h.max = max(h.max, v)
+}
+
+func (h *Histogram) Record(d time.Duration) {
+	h.RecordValue(d.Microseconds())
+}
+
+func (h *Histogram) Merge(other *Histogram) {
+	if other.count == 0 {
+		return
+	}
+
+	if len(other.counts) > len(h.counts) {
+		h.counts = append(h.counts, make([]int64, len(other.counts)-len(h.counts))...)
+	}
+	for i, c := range other.counts {
+		h.counts[i] += c
+	}
+
+	h.count += other.count
+	h.sum += other.sum
+	h.min = min(h.min, other.min)
+	h.max = max(h.max, other.max)
+}
+
+func (h *Histogram) Count() int64 {
+	return h.count
+}
+
+func (h *Histogram) Min() int64 {
+	if h.count == 0 {
+		return 0
+	}
+
+	return h.min
+}
+
+func (h *Histogram) Max() int64 {
+	return h.max
+}
+
+func (h *Histogram) Mean() float64 {
+	if h.count == 0 {
+		return math.NaN()
+	}
+
+	return float64(h.sum) / float64(h.count)
+}
+
+// ValueAtPercentile returns the highest value equivalent to the value at the p-th percentile.
+func (h *Histogram) ValueAtPercentile(p float64) int64 {
+	if h.count == 0 {
+		return 0
+	}
+
+	target := int64(math.Ceil(p / 100 * float64(h.count)))
+	target = max(target, 1)
+
+	var cumulative int64
+	for i, c := range h.counts {
+		cumulative += c
+		if cumulative >= target {
+			return min(bucketHighestValue(i), h.max)
+		}
+	}
+
+	return h.max
+}
+
+func (h *Histogram) Summary() results.LatencySummary {
+	if h.count == 0 {
+		return results.LatencySummary{}
+	}
+
+	ms := func(us int64) float64 {
+		return float64(us) / 1000
+	}
+
+	return results.LatencySummary{
+		Count:  h.count,
+		MinMs:  ms(h.Min()),
+		MeanMs: h.Mean() / 1000,
+		P50Ms:  ms(h.ValueAtPercentile(50)),
+		P90Ms:  ms(h.ValueAtPercentile(90)),
+		P99Ms:  ms(h.ValueAtPercentile(99)),
+		P999Ms: ms(h.ValueAtPercentile(99.9)),
+		MaxMs:  ms(h.Max()),
+	}
+}
@@ -0,0 +1,103 @@
+package workload
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/pausing-clusters-thesis/benchmarks/results"
+)
+
+func TestHistogramBuckets(t *testing.T) {
+	t.Parallel()
+
+	for _, v := range []int64{0, 1, 255, 256, 257, 383, 384, 1000, 123456, 1 << 40} {
+		idx := bucketIndex(v)
+		highest := bucketHighestValue(idx)
+		if highest < v {
+			t.Errorf("expected bucket %d of value %d to have a highest value of at least %d, got %d", idx, v, v, highest)
+		}
+		if bucketIndex(highest) != idx {
+			t.Errorf("expected highest value %d to be in bucket %d, got %d", highest, idx, bucketIndex(highest))
+		}
+		if float64(highest-v)/float64(max(v, 1)) > 1.0/histogramHalfBuckets {
+			t.Errorf("expected value %d to be recorded within the relative error, got highest value %d", v, highest)
+		}
+	}
+
+	for v := int64(0); v < 4*histogramSubBuckets; v++ {
+		if bucketIndex(v+1) < bucketIndex(v) || bucketIndex(v+1) > bucketIndex(v)+1 {
+			t.Fatalf("expected buckets to be contiguous, got %d for %d and %d for %d", bucketIndex(v), v, bucketIndex(v+1), v+1)
+		}
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	t.Parallel()
+
+	h := NewHistogram()
+	for v := int64(1); v <= 10000; v++ {
+		h.RecordValue(v)
+	}
+
+	if h.Count() != 10000 {
+		t.Errorf("expected count 10000, got %d", h.Count())
+	}
+	if h.Min() != 1 || h.Max() != 10000 {
+		t.Errorf("expected min 1 and max 10000, got %d and %d", h.Min(), h.Max())
+	}
+	if h.Mean() != 5000.5 {
+		t.Errorf("expected mean 5000.5, got %v", h.Mean())
+	}
+
+	for _, tc := range []struct {
+		p        float64
+		expected int64
+	}{
+		{p: 0, expected: 1},
+		{p: 1, expected: 100},
+		{p: 50, expected: 5000},
+		{p: 99, expected: 9900},
+		{p: 100, expected: 10000},
+	} {
+		got := h.ValueAtPercentile(tc.p)
+		if math.Abs(float64(got-tc.expected))/float64(tc.expected) > 1.0/histogramHalfBuckets {
+			t.Errorf("expected p%v to be about %d, got %d", tc.p, tc.expected, got)
+		}
+		if got < tc.expected {
+			t.Errorf("expected p%v to be at least %d, got %d", tc.p, tc.expected, got)
+		}
+	}
+}
+
+func TestHistogramMerge(t *testing.T) {
+	t.Parallel()
+
+	a := NewHistogram()
+	a.Record(2 * time.Millisecond)
+	b := NewHistogram()
+	b.Record(500 * time.Microsecond)
+	b.Record(time.Second)
+
+	a.Merge(b)
+	a.Merge(NewHistogram())
+
+	summary := a.Summary()
+	expected := results.LatencySummary{
+		Count:  3,
+		MinMs:  0.5,
+		MeanMs: 334.1666666666667,
+		P50Ms:  2.007,
+		P90Ms:  1000,
+		P99Ms:  1000,
+		P999Ms: 1000,
+		MaxMs:  1000,
+	}
+	if summary != expected {
+		t.Errorf("expected summary %#v, got %#v", expected, summary)
+	}
+
+	if empty := NewHistogram().Summary(); empty != (results.LatencySummary{}) {
+		t.Errorf("expected empty summary, got %#v", empty)
+	}
+}
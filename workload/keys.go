@@ -0,0 +1,42 @@
+package workload
+
+import (
+	"math/rand/v2"
+)
+
+// KeyGenerator chooses the keys of operations. It isn't safe for concurrent use.
+type KeyGenerator interface {
+	Next() int64
+}
+
+type uniformKeyGenerator struct {
+	r     *rand.Rand
+	count int64
+}
+
+func (g *uniformKeyGenerator) Next() int64 {
+	return g.r.Int64N(g.count)
+}
+
+// zipfKeyGenerator chooses low keys most often, so that the hottest keys are the same in every run.
+type zipfKeyGenerator struct {
+	zipf *rand.Zipf
+}
+
+func (g *zipfKeyGenerator) Next() int64 {
+	return int64(g.zipf.Uint64())
+}
+
+func NewKeyGenerator(kd KeyDistribution, r *rand.Rand) KeyGenerator {
+	switch kd.Type {
+	case ZipfDistributionType:
+		return &zipfKeyGenerator{
+			zipf: rand.NewZipf(r, kd.ZipfExponent, 1, uint64(kd.Count-1)),
+		}
+	default:
+		return &uniformKeyGenerator{
+			r:     r,
+			count: kd.Count,
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package workload
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestKeyGenerators(t *testing.T) {
+	t.Parallel()
+
+	const samples = 100000
+
+	tt := []struct {
+		name         string
+		distribution KeyDistribution
+		// The share of samples of the most frequent key has to be within the bounds.
+		minHottestKeyShare float64
+		maxHottestKeyShare float64
+	}{
+		{
+			name:               "uniform",
+			distribution:       KeyDistribution{Type: UniformDistributionType, Count: 1000},
+			minHottestKeyShare: 0,
+			maxHottestKeyShare: 0.01,
+		},
+		{
+			name:               "zipf",
+			distribution:       KeyDistribution{Type: ZipfDistributionType, Count: 1000, ZipfExponent: 1.5},
+			minHottestKeyShare: 0.2,
+			maxHottestKeyShare: 1,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			g := NewKeyGenerator(tc.distribution, rand.New(rand.NewPCG(1, 2)))
+			counts := map[int64]int{}
+			for range samples {
+				key := g.Next()
+				if key < 0 || key >= tc.distribution.Count {
+					t.Fatalf("expected key in [0, %d), got %d", tc.distribution.Count, key)
+				}
+				counts[key]++
+			}
+
+			hottest := 0
+			for _, c := range counts {
+				hottest = max(hottest, c)
+			}
+			share := float64(hottest) / samples
+			if share < tc.minHottestKeyShare || share > tc.maxHottestKeyShare {
+				t.Errorf("expected the hottest key share to be in [%v, %v], got %v", tc.minHottestKeyShare, tc.maxHottestKeyShare, share)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package workload
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/gocql/gocql"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+type DistributionType string
+
+const (
+	UniformDistributionType DistributionType = "uniform"
+	ZipfDistributionType    DistributionType = "zipf"
+)
+
+var identifierRegexp = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]{0,47}$`)
+
+// Schema describes the table the workload runs against. Rows have a bigint key and a blob value.
+type Schema struct {
+	Keyspace          string `json:"keyspace"`
+	Table             string `json:"table"`
+	ReplicationFactor int    `json:"replicationFactor"`
+}
+
+func (s *Schema) Validate() error {
+	var errs []error
+
+	if !identifierRegexp.MatchString(s.Keyspace) {
+		errs = append(errs, fmt.Errorf("keyspace %q isn't a valid CQL identifier", s.Keyspace))
+	}
+
+	if !identifierRegexp.MatchString(s.Table) {
+		errs = append(errs, fmt.Errorf("table %q isn't a valid CQL identifier", s.Table))
+	}
+
+	if s.ReplicationFactor <= 0 {
+		errs = append(errs, fmt.Errorf("replication factor must be greater than zero, got %d", s.ReplicationFactor))
+	}
+
+	return errors.Join(errs...)
+}
+
+// KeyDistribution describes how keys of operations are chosen out of Count keys.
+type KeyDistribution struct {
+	Type  DistributionType `json:"type"`
+	Count int64            `json:"count"`
+	// ZipfExponent is the exponent of the zipf distribution. It must be greater than 1.
+	ZipfExponent float64 `json:"zipfExponent,omitempty"`
+}
+
+func (kd *KeyDistribution) Validate() error {
+	var errs []error
+
+	if kd.Count <= 0 {
+		errs = append(errs, fmt.Errorf("count must be greater than zero, got %d", kd.Count))
+	}
+
+	switch kd.Type {
+	case UniformDistributionType:
+	case ZipfDistributionType:
+		if kd.ZipfExponent <= 1 {
+			errs = append(errs, fmt.Errorf("zipf exponent must be greater than 1, got %v", kd.ZipfExponent))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("unsupported distribution type %q, supported types are: %v", kd.Type, []DistributionType{UniformDistributionType, ZipfDistributionType}))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Spec describes a fixed-rate mix of reads and writes of single rows.
+type Spec struct {
+	Schema       Schema          `json:"schema"`
+	Keys         KeyDistribution `json:"keys"`
+	RowSizeBytes int             `json:"rowSizeBytes"`
+	// ReadRatio is the fraction of operations that are reads, the rest are writes.
+	ReadRatio   float64 `json:"readRatio"`
+	Consistency string  `json:"consistency"`
+	// Rate is the number of operations per second issued regardless of how fast they complete.
+	Rate        int             `json:"rate"`
+	Duration    metav1.Duration `json:"duration"`
+	Concurrency int             `json:"concurrency"`
+}
+
+func (s *Spec) Validate() error {
+	var errs []error
+
+	err := s.Schema.Validate()
+	if err != nil {
+		errs = append(errs, fmt.Errorf("invalid schema: %w", err))
+	}
+
+	err = s.Keys.Validate()
+	if err != nil {
+		errs = append(errs, fmt.Errorf("invalid keys: %w", err))
+	}
+
+	if s.RowSizeBytes < 0 {
+		errs = append(errs, fmt.Errorf("row size must not be negative, got %d", s.RowSizeBytes))
+	}
+
+	if s.ReadRatio < 0 || s.ReadRatio > 1 {
+		errs = append(errs, fmt.Errorf("read ratio must be between 0 and 1, got %v", s.ReadRatio))
+	}
+
+	_, err = gocql.ParseConsistencyWrapper(s.Consistency)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("invalid consistency: %w", err))
+	}
+
+	if s.Rate <= 0 {
+		errs = append(errs, fmt.Errorf("rate must be greater than zero, got %d", s.Rate))
+	}
+
+	if s.Duration.Duration <= 0 {
+		errs = append(errs, fmt.Errorf("duration must be greater than zero, got %v", s.Duration.Duration))
+	}
+
+	if s.Concurrency <= 0 {
+		errs = append(errs, fmt.Errorf("concurrency must be greater than zero, got %d", s.Concurrency))
+	}
+
+	return errors.Join(errs...)
+}
+
+func ReadSpecFile(filePath string) (*Spec, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("can't read workload spec file %q: %w", filePath, err)
+	}
+
+	spec := &Spec{}
+	err = yaml.UnmarshalStrict(data, spec)
+	if err != nil {
+		return nil, fmt.Errorf("can't decode workload spec file %q: %w", filePath, err)
+	}
+
+	err = spec.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid workload spec file %q: %w", filePath, err)
+	}
+
+	return spec, nil
+}
@@ -0,0 +1,45 @@
+package workload
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReadSpecFile(t *testing.T) {
+	t.Parallel()
+
+	spec, err := ReadSpecFile(filepath.Join("testdata", "workload.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if spec.Keys.Type != ZipfDistributionType || spec.Keys.Count != 100000 || spec.Keys.ZipfExponent != 1.1 {
+		t.Errorf("unexpected key distribution %#v", spec.Keys)
+	}
+	if spec.Duration.Duration != 2*time.Minute {
+		t.Errorf("expected duration 2m, got %v", spec.Duration.Duration)
+	}
+	if spec.Consistency != "LOCAL_QUORUM" || spec.Rate != 500 || spec.ReadRatio != 0.9 {
+		t.Errorf("unexpected spec %#v", spec)
+	}
+
+	for _, content := range []string{
+		"rates: 1\n",
+		"schema:\n  keyspace: ks\n  table: kv\n  replicationFactor: 1\nkeys:\n  type: zipf\n  count: 10\n  zipfExponent: 1\nconsistency: ONE\nrate: 1\nduration: 1s\nconcurrency: 1\n",
+		"schema:\n  keyspace: ks\n  table: kv\n  replicationFactor: 1\nkeys:\n  type: uniform\n  count: 10\nconsistency: SOME\nrate: 1\nduration: 1s\nconcurrency: 1\n",
+		"schema:\n  keyspace: ks; DROP\n  table: kv\n  replicationFactor: 1\nkeys:\n  type: uniform\n  count: 10\nconsistency: ONE\nrate: 1\nduration: 1s\nconcurrency: 1\n",
+	} {
+		filePath := filepath.Join(t.TempDir(), "workload.yaml")
+		err = os.WriteFile(filePath, []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = ReadSpecFile(filePath)
+		if err == nil {
+			t.Errorf("expected an error for spec %q", content)
+		}
+	}
+}
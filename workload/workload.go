@@ -0,0 +1,200 @@
+package workload
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/scylladb/gocqlx/v2"
+	"github.com/scylladb/gocqlx/v2/table"
+)
+
+type OperationType string
+
+const (
+	ReadOperationType  OperationType = "read"
+	WriteOperationType OperationType = "write"
+)
+
+// Operation is the outcome of a single operation.
+// Latency is measured from the time the operation was scheduled, so that a saturated cluster doesn't lower the rate
+// at which latency is sampled.
+type Operation struct {
+	Type           OperationType
+	Key            int64
+	ScheduledTime  time.Time
+	CompletionTime time.Time
+	Err            error
+}
+
+func (o *Operation) Latency() time.Duration {
+	return o.CompletionTime.Sub(o.ScheduledTime)
+}
+
+type row struct {
+	Key   int64
+	Value []byte
+}
+
+func newTable(schema Schema) *table.Table {
+	return table.New(table.Metadata{
+		Name:    fmt.Sprintf("%s.%s", schema.Keyspace, schema.Table),
+		Columns: []string{"key", "value"},
+		PartKey: []string{"key"},
+	})
+}
+
+// Prepare creates the table of the workload. It has to be called while the cluster is available.
+func Prepare(ctx context.Context, session gocqlx.Session, schema Schema) error {
+	err := schema.Validate()
+	if err != nil {
+		return fmt.Errorf("invalid schema: %w", err)
+	}
+
+	err = session.ContextQuery(ctx, fmt.Sprintf(
+		`CREATE KEYSPACE IF NOT EXISTS %q WITH replication = {'class': 'NetworkTopologyStrategy', 'replication_factor': %d}`,
+		schema.Keyspace,
+		schema.ReplicationFactor,
+	), nil).ExecRelease()
+	if err != nil {
+		return fmt.Errorf("can't create keyspace %q: %w", schema.Keyspace, err)
+	}
+
+	err = session.ContextQuery(ctx, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %q.%q (key bigint PRIMARY KEY, value blob)`, schema.Keyspace, schema.Table), nil).ExecRelease()
+	if err != nil {
+		return fmt.Errorf("can't create table %q: %w", schema.Table, err)
+	}
+
+	return nil
+}
+
+type Options struct {
+	Seed uint64
+	// OnOperation is called after every operation, concurrently (optional).
+	OnOperation func(Operation)
+}
+
+// Result holds latency histograms of successful operations.
+type Result struct {
+	StartTime time.Time
+	Duration  time.Duration
+	Reads     *Histogram
+	Writes    *Histogram
+	Errors    int64
+}
+
+func (r *Result) Operations() int64 {
+	return r.Reads.Count() + r.Writes.Count()
+}
+
+// Throughput returns the number of successful operations per second.
+func (r *Result) Throughput() float64 {
+	return float64(r.Operations()) / r.Duration.Seconds()
+}
+
+// Run runs the workload for the duration in the spec or until ctx is done. Cancelling ctx stops the workload
+// without an error.
+func Run(ctx context.Context, session gocqlx.Session, spec Spec, options Options) (*Result, error) {
+	err := spec.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid workload spec: %w", err)
+	}
+
+	consistency, err := gocql.ParseConsistencyWrapper(spec.Consistency)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, spec.Duration.Duration)
+	defer cancel()
+
+	t := newTable(spec.Schema)
+	startTime := time.Now()
+	result := &Result{
+		StartTime: startTime,
+		Reads:     NewHistogram(),
+		Writes:    NewHistogram(),
+	}
+
+	opCh := make(chan Operation, spec.Concurrency)
+	go func() {
+		defer close(opCh)
+
+		r := rand.New(rand.NewPCG(options.Seed, uint64(startTime.UnixNano())))
+		keys := NewKeyGenerator(spec.Keys, r)
+		interval := time.Second / time.Duration(spec.Rate)
+		for i := time.Duration(0); ; i++ {
+			scheduledTime := startTime.Add(i * interval)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Until(scheduledTime)):
+			}
+
+			op := Operation{
+				Type:          WriteOperationType,
+				Key:           keys.Next(),
+				ScheduledTime: scheduledTime,
+			}
+			if r.Float64() < spec.ReadRatio {
+				op.Type = ReadOperationType
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case opCh <- op:
+			}
+		}
+	}()
+
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	for range spec.Concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			value := make([]byte, spec.RowSizeBytes)
+			for op := range opCh {
+				switch op.Type {
+				case ReadOperationType:
+					var rows []row
+					op.Err = t.SelectQueryContext(ctx, session).Consistency(consistency).BindMap(map[string]interface{}{"key": op.Key}).SelectRelease(&rows)
+				case WriteOperationType:
+					op.Err = t.InsertQueryContext(ctx, session).Consistency(consistency).BindStruct(&row{Key: op.Key, Value: value}).ExecRelease()
+				}
+				op.CompletionTime = time.Now()
+
+				// Operations interrupted by the end of the workload aren't measured.
+				if op.Err != nil && ctx.Err() != nil {
+					continue
+				}
+
+				lock.Lock()
+				switch {
+				case op.Err != nil:
+					result.Errors++
+				case op.Type == ReadOperationType:
+					result.Reads.Record(op.Latency())
+				default:
+					result.Writes.Record(op.Latency())
+				}
+				lock.Unlock()
+
+				if options.OnOperation != nil {
+					options.OnOperation(op)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	result.Duration = time.Since(startTime)
+
+	return result, nil
+}